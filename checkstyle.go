@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"go/ast"
-	"go/format"
+	"go/importer"
 	"go/parser"
+	"go/scanner"
 	"go/token"
-	"strconv"
+	"go/types"
+	"sort"
 	"strings"
 )
 
@@ -21,369 +23,379 @@ const (
 	Formated     ProblemType = "formated"
 	PackageName  ProblemType = "pkg_name"
 	CamelName    ProblemType = "camel_name"
+	MaxIndent    ProblemType = "max_indent"
+	FuncComment  ProblemType = "func_comment"
+	Cyclomatic   ProblemType = "cyclomatic"
+	Cognitive    ProblemType = "cognitive"
+	LineLength   ProblemType = "line_length"
+	ReceiverName ProblemType = "receiver_name"
+	Initialism   ProblemType = "initialism"
+	ErrorString  ProblemType = "error_string"
+	ErrorName    ProblemType = "error_name"
+	ContextFirst ProblemType = "context_first"
+	ErrorLast    ProblemType = "error_last"
+	BoolParams   ProblemType = "bool_params"
+	NakedReturn  ProblemType = "naked_return"
+	ImportGroup  ProblemType = "import_group"
+	Imports      ProblemType = "imports"
+	Suppress     ProblemType = "suppress"
+	// ParseError is reported for the files which could not be parsed, they
+	// are not checked by the rules.
+	ParseError ProblemType = "parse_error"
 )
 
 type Problem struct {
 	Position    *token.Position
 	Description string
 	// SourceLine  string
-	Type ProblemType
+	Type     ProblemType
+	Severity Severity
+	// Fix is the suggested edits of the file, it is empty if the problem
+	// could not be fixed automatically.
+	Fix []TextEdit
+	// Data is the values measured by the rule, such as the complexity of
+	// a function, for the reporters.
+	Data map[string]interface{}
 }
 
 type Checker interface {
 	Check(fileName string, src []byte) ([]Problem, error)
+	// CheckPackage checks the files of a directory together, files maps
+	// the file name to its source. The files which could not be parsed are
+	// reported as ParseError problems.
+	CheckPackage(files map[string][]byte) ([]Problem, error)
 	IsFatal(p *Problem) bool
+	Severity(t ProblemType) Severity
 }
 
 type checker struct {
-	FunctionComment bool     `json:"func_comment"`
-	FileLine        int      `json:"file_line"`
-	FunctionLine    int      `json:"func_line"`
-	MaxIndent       int      `json:"max_indent"`
-	Formated        bool     `json:"formated"`
-	Fatal           []string `json:"fatal"`
-	ParamsNum       int      `json:"params_num"`
-	ResultsNum      int      `json:"results_num"`
-	PackageName     bool     `json:"pkg_name"`
-	CamelName       bool     `json:"camel_name"`
+	Fatal          []string            `json:"fatal"`
+	Severities     map[string]Severity `json:"severity"`
+	TypeCheck      bool                `json:"type_check"`
+	SuppressReason bool                `json:"suppress_reason"`
+	// Tests overrides the rule configs for _test.go files.
+	Tests     json.RawMessage `json:"tests"`
+	Generated generatedMode   `json:"generated"`
+
+	rules     []Rule
+	testRules []Rule
+	importer  types.Importer
 }
 
+// New returns a checker of the config, which could be JSON, YAML or TOML.
+// The file paths in extends are relative to the current directory.
 func New(config []byte) (Checker, error) {
+	config, err := ResolveConfig(config, ".")
+	if err != nil {
+		return nil, err
+	}
 	var _checker checker
-	err := json.Unmarshal(config, &_checker)
+	err = json.Unmarshal(config, &_checker)
+	if err != nil {
+		return nil, err
+	}
+	_checker.rules, err = _checker.newRules(config)
+	if err != nil {
+		return nil, err
+	}
+	err = _checker.newTestRules(config)
 	if err != nil {
 		return nil, err
 	}
 	return &_checker, nil
 }
 
-func (c *checker) Check(fileName string, src []byte) (ps []Problem, err error) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+func (c *checker) newRules(config []byte) ([]Rule, error) {
+	rules, err := newRules(config)
 	if err != nil {
 		return nil, err
 	}
-	return (&file{fileName, src, c, f, fset, []Problem{}}).check(), nil
-}
-
-func (c *checker) IsFatal(p *Problem) bool {
-	for _, v := range c.Fatal {
-		if v == string(p.Type) {
-			return true
+	var enabled []Rule
+	for _, rule := range rules {
+		if c.Severity(rule.Type()) != SeverityOff {
+			enabled = append(enabled, rule)
 		}
 	}
-	return false
+	return enabled, nil
 }
 
-type file struct {
-	fileName string
-	src      []byte
-
-	config *checker
-
-	ast  *ast.File
-	fset *token.FileSet
-
-	problems []Problem
+// newTestRules creates the rules checking _test.go files. They are the
+// rules of the config merged with the tests block, or the rules checking
+// tests if there is no tests block.
+func (c *checker) newTestRules(config []byte) error {
+	if c.Tests == nil {
+		for _, rule := range c.rules {
+			if checksTests(rule) {
+				c.testRules = append(c.testRules, rule)
+			}
+		}
+		return nil
+	}
+	config, err := MergeConfig(config, c.Tests)
+	if err == nil {
+		c.testRules, err = c.newRules(config)
+	}
+	if e, ok := err.(*ConfigError); ok {
+		e.Key = "tests." + e.Key
+	}
+	return err
 }
 
-func (f *file) isTest() bool {
-	return strings.HasSuffix(f.fileName, "_test.go")
+func (c *checker) Check(fileName string, src []byte) (ps []Problem, err error) {
+	return c.CheckPackage(map[string][]byte{fileName: src})
 }
 
-func (f *file) check() (ps []Problem) {
-	f.checkFormat()
-	f.checkFileLine()
-	f.checkFileContent()
-	return f.problems
-}
+func (c *checker) CheckPackage(files map[string][]byte) (ps []Problem, err error) {
+	fileNames := make([]string, 0, len(files))
+	for k := range files {
+		fileNames = append(fileNames, k)
+	}
+	sort.Strings(fileNames)
 
-func (f *file) checkFormat() {
-	if !f.config.Formated {
-		return
+	fset := token.NewFileSet()
+	var pkgs []*Package
+	for _, fileName := range fileNames {
+		f, err := parser.ParseFile(fset, fileName, files[fileName], parser.ParseComments)
+		if err != nil {
+			ps = append(ps, genParseErrorProblem(fileName, files[fileName], err))
+			continue
+		}
+		file := &File{FileName: fileName, Src: files[fileName], AST: f, Fset: fset, Generated: isGenerated(f)}
+		pkgs = addFile(pkgs, file)
 	}
-	src, err := format.Source(f.src)
-	if err != nil {
-		panic(f.fileName + err.Error())
+	for _, pkg := range pkgs {
+		if c.TypeCheck {
+			pkg.typeCheck(c.sourceImporter())
+		}
+		pkg.check(c.rules, c.testRules, c.Tests != nil, c.Generated == generatedSkip)
+		for _, f := range pkg.Files {
+			f.suppress(c.SuppressReason)
+			ps = append(ps, c.generatedProblems(f)...)
+		}
 	}
-	if len(src) != len(f.src) || bytes.Compare(src, f.src) != 0 {
-		desc := "source is not formated"
-		pos := f.fset.Position(f.ast.Pos())
-		problem := Problem{Description: desc, Position: &pos, Type: Formated}
-		f.problems = append(f.problems, problem)
+	for i := range ps {
+		if ps[i].Severity == SeverityOff {
+			ps[i].Severity = c.Severity(ps[i].Type)
+		}
 	}
+	return ps, nil
 }
 
-func (f *file) checkFileLine() {
-	if f.isTest() {
-		return
+func genParseErrorProblem(fileName string, src []byte, err error) Problem {
+	start := token.Position{Filename: fileName}
+	desc := err.Error()
+	if list, ok := err.(scanner.ErrorList); ok && len(list) != 0 {
+		// the position of the error is adjusted by the //line directives,
+		// only its offset is in the file
+		offset := list[0].Pos.Offset
+		start.Offset = offset
+		start.Line = bytes.Count(src[:offset], []byte("\n")) + 1
+		start.Column = offset - bytes.LastIndexByte(src[:offset], '\n')
+		desc = list[0].Msg
 	}
+	return Problem{Description: desc, Position: &start, Type: ParseError}
+}
 
-	lineLimit := f.config.FileLine
-	if lineLimit == 0 {
-		return
+func (c *checker) sourceImporter() types.Importer {
+	if c.importer == nil {
+		c.importer = importer.ForCompiler(token.NewFileSet(), "source", nil)
 	}
+	return c.importer
+}
 
-	f.fset.Iterate(func(_file *token.File) bool {
-		lineCount := _file.LineCount()
-		if lineCount > lineLimit {
-			desc := strconv.Itoa(lineCount) + " lines more than " + strconv.Itoa(lineLimit)
-			pos := f.fset.Position(f.ast.End())
-			problem := Problem{Description: desc, Position: &pos, Type: FileLine}
-			f.problems = append(f.problems, problem)
-		}
-		return true
-	})
+// File is a source file under checking, it is passed to the rules.
+type File struct {
+	FileName string
+	Src      []byte
+
+	AST     *ast.File
+	Fset    *token.FileSet
+	Package *Package
+	// TypesInfo is nil unless type_check is enabled in the config, it may
+	// be incomplete if the package has type errors.
+	TypesInfo *types.Info
+	// Generated is true if the file has the comment of generated code.
+	Generated bool
+
+	problems []Problem
 }
 
-func genFuncLineProblem(name string, lineCount, lineLimit int, start token.Position) Problem {
-	desc := "func " + name + "() body lines num " + strconv.Itoa(lineCount) +
-		" more than " + strconv.Itoa(lineLimit)
-	return Problem{Description: desc, Position: &start, Type: FunctionLine}
+func (f *File) IsTest() bool {
+	return strings.HasSuffix(f.FileName, "_test.go")
 }
 
-func genParamsNumProblem(name string, paramsNum, limit int, start token.Position) Problem {
-	desc := "func " + name + "() params num " + strconv.Itoa(paramsNum) +
-		"  more than " + strconv.Itoa(limit)
-	return Problem{Description: desc, Position: &start, Type: ParamsNum}
+// Report adds a problem of type t at pos to the file.
+func (f *File) Report(pos token.Pos, t ProblemType, desc string) {
+	f.ReportFix(pos, t, desc, nil)
 }
 
-func genResultsNumProblem(name string, resultsNum, limit int, start token.Position) Problem {
-	desc := "func " + name + "() results num " + strconv.Itoa(resultsNum) +
-		"  more than " + strconv.Itoa(limit)
-	return Problem{Description: desc, Position: &start, Type: ResultsNum}
+// ReportFix adds a problem of type t at pos to the file, with the edits
+// fixing it. The position is in the file, it is not adjusted by the //line
+// directives.
+func (f *File) ReportFix(pos token.Pos, t ProblemType, desc string, fix []TextEdit) {
+	start := f.Fset.PositionFor(pos, false)
+	problem := Problem{Description: desc, Position: &start, Type: t, Fix: fix}
+	f.problems = append(f.problems, problem)
 }
 
-func genFuncBodyProblem(name string, start token.Position) Problem {
-	desc := "func " + name + " expected block '{}'"
-	return Problem{Description: desc, Position: &start, Type: ResultsNum}
+// ReportData adds a problem of type t at pos to the file, with the values
+// measured by the rule.
+func (f *File) ReportData(pos token.Pos, t ProblemType, desc string, data map[string]interface{}) {
+	f.Report(pos, t, desc)
+	f.problems[len(f.problems)-1].Data = data
 }
 
-func (f *file) checkPkgName(pkg *ast.Ident) {
-	//ref "http://golang.org/doc/effective_go.html#package-names"
-	pkgName := pkg.Name
-	var desc string
-	if strings.Contains(pkgName, "_") {
-		suggestName := strings.Replace(pkgName, "_", "/", -1)
-		desc = "don't use an underscore in package name, " + pkgName + " should be " + suggestName
-	} else if strings.ToLower(pkgName) != pkgName {
-		desc = "don't use capital letters in package name: " + pkgName
-	}
-	if desc != "" {
-		start := f.fset.Position(pkg.Pos())
-		problem := Problem{Description: desc, Position: &start, Type: PackageName}
-		f.problems = append(f.problems, problem)
-	}
+// ReportSeverity adds a problem of type t at pos to the file, with the
+// severity instead of the one configured for t if it is not SeverityOff.
+func (f *File) ReportSeverity(pos token.Pos, t ProblemType, desc string, severity Severity) {
+	f.Report(pos, t, desc)
+	f.problems[len(f.problems)-1].Severity = severity
 }
 
-func (f *file) checkFunctionParams(fType *ast.FuncType, funcName string) {
-	paramsNumLimit := f.config.ParamsNum
-	resultsNumLimit := f.config.ResultsNum
-	params := fType.Params
-	if params != nil {
-		if paramsNumLimit != 0 && params.NumFields() > paramsNumLimit {
-			start := f.fset.Position(params.Pos())
-			problem := genParamsNumProblem(funcName, params.NumFields(), paramsNumLimit, start)
-			f.problems = append(f.problems, problem)
-		}
-		for _, v := range params.List {
-			for _, pName := range v.Names {
-				f.checkName(pName, "param", true)
-			}
-		}
-	}
+// Package is the files of a package in a directory, which are parsed
+// into one FileSet.
+type Package struct {
+	Name  string
+	Files []*File
+	Fset  *token.FileSet
 
-	results := fType.Results
-	if results != nil {
-		if resultsNumLimit != 0 && results != nil && results.NumFields() > resultsNumLimit {
-			start := f.fset.Position(results.Pos())
-			problem := genResultsNumProblem(funcName, results.NumFields(), resultsNumLimit, start)
-			f.problems = append(f.problems, problem)
-		}
+	Types      *types.Package
+	TypesInfo  *types.Info
+	TypeErrors []error
+}
 
-		for _, v := range results.List {
-			for _, rName := range v.Names {
-				f.checkName(rName, "return param", true)
-			}
+func addFile(pkgs []*Package, f *File) []*Package {
+	for _, pkg := range pkgs {
+		if pkg.Name == f.AST.Name.Name {
+			pkg.Files = append(pkg.Files, f)
+			f.Package = pkg
+			return pkgs
 		}
 	}
+	f.Package = &Package{Name: f.AST.Name.Name, Files: []*File{f}, Fset: f.Fset}
+	return append(pkgs, f.Package)
 }
 
-func (f *file) checkFunctionLine(funcDecl *ast.FuncDecl) {
-	lineLimit := f.config.FunctionLine
-	if lineLimit <= 0 {
-		return
-	}
-	start := f.fset.Position(funcDecl.Pos())
-
-	startLine := start.Line
-	endLine := f.fset.Position(funcDecl.End()).Line
-	lineCount := endLine - startLine
-	if lineCount > lineLimit {
-		problem := genFuncLineProblem(funcDecl.Name.Name, lineCount, lineLimit, start)
-		f.problems = append(f.problems, problem)
+// Report adds a problem of type t at pos to the file containing pos.
+func (p *Package) Report(pos token.Pos, t ProblemType, desc string) {
+	fileName := p.Fset.PositionFor(pos, false).Filename
+	for _, f := range p.Files {
+		if f.FileName == fileName {
+			f.Report(pos, t, desc)
+			return
+		}
 	}
 }
 
-func (f *file) checkFunctionBody(funcDecl *ast.FuncDecl) {
-	if funcDecl.Body != nil {
-		return
+func (p *Package) typeCheck(imp types.Importer) {
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
 	}
-	start := f.fset.Position(funcDecl.Pos())
-	problem := genFuncBodyProblem(funcDecl.Name.Name, start)
-	f.problems = append(f.problems, problem)
-}
-
-func (f *file) checkFunctionDeclare(funcDecl *ast.FuncDecl) {
-	f.checkFunctionLine(funcDecl)
-	f.checkName(funcDecl.Name, "func", false)
-	f.checkFunctionParams(funcDecl.Type, funcDecl.Name.Name)
-	f.checkFunctionBody(funcDecl)
-	receiver := funcDecl.Recv
-	if receiver != nil && len(receiver.List) != 0 && len(receiver.List[0].Names) != 0 {
-		f.checkName(receiver.List[0].Names[0], "receiver", true)
+	conf := types.Config{
+		Importer:    imp,
+		FakeImportC: true,
+		Error: func(err error) {
+			p.TypeErrors = append(p.TypeErrors, err)
+		},
 	}
-}
-
-func trimUnderscorePrefix(name string) string {
-	if name[0] == '_' {
-		return name[1:]
+	files := make([]*ast.File, len(p.Files))
+	for i, f := range p.Files {
+		files[i] = f.AST
+		f.TypesInfo = info
 	}
-	return name
+	// the errors are collected by conf.Error, rules use the partial info
+	p.Types, _ = conf.Check(p.Name, p.Fset, files, info)
+	p.TypesInfo = info
 }
 
-func (f *file) checkName(id *ast.Ident, kind string, notFirstCap bool) {
-	if !f.config.CamelName {
-		return
-	}
-	name := trimUnderscorePrefix(id.Name)
-	if name == "" {
-		return
-	}
-	start := f.fset.Position(id.Pos())
-
-	if strings.Contains(name, "_") {
-		desc := "don't use non-prefix underscores in " + kind + " name: " + id.Name + ", please use camel name"
-		problem := Problem{Description: desc, Position: &start, Type: CamelName}
-		f.problems = append(f.problems, problem)
-	} else if len(name) >= 5 && strings.ToUpper(name) == name {
-		desc := "don't use all captial letters in " + kind + " name: " + id.Name + ", please use camel name"
-		problem := Problem{Description: desc, Position: &start, Type: CamelName}
-		f.problems = append(f.problems, problem)
-	} else if notFirstCap && name[0:1] == strings.ToUpper(name[0:1]) {
-		desc := "in function ,don't use first captial letter in " + kind + " name: " + id.Name + ", please use small letter"
-		problem := Problem{Description: desc, Position: &start, Type: CamelName}
-		f.problems = append(f.problems, problem)
-	}
+func (p *Package) withoutTests() *Package {
+	return p.filter(func(f *File) bool { return !f.IsTest() })
 }
 
-func (f *file) checkStruct(st *ast.StructType) {
-	if st.Fields == nil {
-		return
-	}
-	for _, v := range st.Fields.List {
-		for _, v2 := range v.Names {
-			f.checkName(v2, "struct field", false)
-		}
-	}
+func (p *Package) withoutGenerated() *Package {
+	return p.filter(func(f *File) bool { return !f.Generated })
 }
 
-func (f *file) checkInterface(it *ast.InterfaceType) {
-	if it.Methods == nil {
-		return
-	}
-	for _, v := range it.Methods.List {
-		for _, v2 := range v.Names {
-			f.checkName(v2, "interface method", false)
-		}
-		if v3, ok := v.Type.(*ast.FuncType); ok {
-			f.checkFunctionParams(v3, v.Names[0].Name)
+// filter returns the view of the package with the files kept.
+func (p *Package) filter(keep func(f *File) bool) *Package {
+	pkg := &Package{Name: p.Name, Fset: p.Fset, Types: p.Types, TypesInfo: p.TypesInfo, TypeErrors: p.TypeErrors}
+	for _, f := range p.Files {
+		if keep(f) {
+			pkg.Files = append(pkg.Files, f)
 		}
 	}
+	return pkg
 }
 
-func (f *file) checkValueName(decl *ast.GenDecl, kind string, top bool) {
-	for _, spec := range decl.Specs {
-		if vSpec, ok := spec.(*ast.ValueSpec); ok {
-			for _, name := range vSpec.Names {
-				f.checkName(name, kind, !top)
-			}
-		} else if tSpec, ok := spec.(*ast.TypeSpec); ok {
-			f.checkName(tSpec.Name, kind, false)
-			ast.Inspect(tSpec.Type, func(node ast.Node) bool {
-				switch decl2 := node.(type) {
-				case *ast.GenDecl:
-					f.checkGenDecl(decl2, false)
-				case *ast.FuncDecl:
-					f.checkFunctionDeclare(decl2)
-				case *ast.StructType:
-					f.checkStruct(decl2)
-				case *ast.InterfaceType:
-					f.checkInterface(decl2)
-				}
-				return true
-			})
-		} else if iSpec, ok := spec.(*ast.ImportSpec); ok && iSpec.Name != nil {
-			f.checkName(iSpec.Name, "import", true)
+func (p *Package) check(rules, testRules []Rule, testsBlock, skipGenerated bool) {
+	for _, f := range p.Files {
+		if f.Generated && skipGenerated {
+			continue
+		} else if f.IsTest() {
+			f.check(testRules)
+		} else {
+			f.check(rules)
 		}
 	}
-}
-
-func (f *file) checkGenDecl(decl *ast.GenDecl, top bool) {
-	if decl.Tok == token.CONST {
-		f.checkValueName(decl, "const", top)
-	} else if decl.Tok == token.VAR {
-		f.checkValueName(decl, "var", top)
-	} else if decl.Tok == token.TYPE {
-		f.checkValueName(decl, "type", top)
-	} else if decl.Tok == token.IMPORT {
-		f.checkValueName(decl, "import", true)
+	// the package rules don't see the skipped generated files
+	all := p
+	if skipGenerated {
+		all = p.withoutGenerated()
 	}
-}
-
-func (f *file) checkAssign(assign *ast.AssignStmt) {
-	if assign.Tok != token.DEFINE {
+	if !testsBlock {
+		checkPackage(rules, func(rule Rule) *Package {
+			if checksTests(rule) {
+				return all
+			}
+			return all.withoutTests()
+		})
 		return
 	}
+	// the rules of the tests block check the test files apart
+	files, tests := all.withoutTests(), all.filter((*File).IsTest)
+	checkPackage(rules, func(Rule) *Package { return files })
+	checkPackage(testRules, func(Rule) *Package { return tests })
+}
 
-	for _, v2 := range assign.Lhs {
-		if assignName, ok := v2.(*ast.Ident); ok {
-			f.checkName(assignName, "var", true)
+// checkPackage calls the package rules with the view of the package for
+// each rule, the rules are skipped if the view has no files.
+func checkPackage(rules []Rule, view func(rule Rule) *Package) {
+	for _, rule := range rules {
+		r, ok := rule.(PackageRule)
+		if !ok {
+			continue
+		}
+		if pkg := view(rule); len(pkg.Files) != 0 {
+			r.CheckPackage(pkg)
 		}
 	}
 }
 
-func (f *file) checkFileContent() {
-	if f.isTest() {
-		return
+func (f *File) check(rules []Rule) {
+	var nodeRules []NodeRule
+	for _, rule := range rules {
+		if r, ok := rule.(FileRule); ok {
+			r.CheckFile(f)
+		}
+		if r, ok := rule.(NodeRule); ok {
+			nodeRules = append(nodeRules, r)
+		}
 	}
-
-	if f.config.PackageName {
-		f.checkPkgName(f.ast.Name)
+	if len(nodeRules) == 0 {
+		return
 	}
-
-	for _, v := range f.ast.Decls {
-		switch decl := v.(type) {
-		case *ast.FuncDecl:
-			f.checkFunctionDeclare(decl)
-			if decl.Body == nil {
-				break
-			}
-			ast.Inspect(decl.Body, func(node ast.Node) bool {
-				switch decl2 := node.(type) {
-				case *ast.GenDecl:
-					f.checkGenDecl(decl2, false)
-				case *ast.FuncDecl:
-					f.checkFunctionDeclare(decl2)
-				case *ast.AssignStmt:
-					f.checkAssign(decl2)
-				case *ast.StructType:
-					f.checkStruct(decl2)
-				}
-				return true
-			})
-		case *ast.GenDecl:
-			f.checkGenDecl(decl, true)
+	ast.Inspect(f.AST, func(node ast.Node) bool {
+		if node == nil {
+			return false
 		}
-	}
+		for _, r := range nodeRules {
+			r.CheckNode(f, node)
+		}
+		return true
+	})
 }
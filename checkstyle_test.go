@@ -15,10 +15,18 @@ func readFile(fileName string) []byte {
 	return file
 }
 
+func newChecker(config string) Checker {
+	_checker, err := New([]byte(config))
+	if err != nil {
+		panic(err)
+	}
+	return _checker
+}
+
 func TestFileLine(t *testing.T) {
 	fileName := "fileline.go"
 	file := readFile(fileName)
-	_checkerOk := checker{FileLine: 9}
+	_checkerOk := newChecker(`{"file_line": 9}`)
 	ps, err := _checkerOk.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -27,7 +35,7 @@ func TestFileLine(t *testing.T) {
 		t.Fatal("expect no error")
 	}
 
-	_checkerFail := checker{FileLine: 8}
+	_checkerFail := newChecker(`{"file_line": 8}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 1 || ps[0].Type != FileLine {
 		t.Fatal("expect an error")
@@ -44,7 +52,7 @@ func TestFileLine(t *testing.T) {
 func TestFunctionLine(t *testing.T) {
 	fileName := "functionline.go"
 	file := readFile(fileName)
-	_checkerOk := checker{FunctionLine: 9}
+	_checkerOk := newChecker(`{"func_line": 9}`)
 	ps, err := _checkerOk.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -53,7 +61,7 @@ func TestFunctionLine(t *testing.T) {
 		t.Fatal("expect no error")
 	}
 
-	_checkerFail := checker{FunctionLine: 8}
+	_checkerFail := newChecker(`{"func_line": 8}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 1 || ps[0].Type != FunctionLine {
 		t.Fatal("expect an error")
@@ -71,7 +79,7 @@ func TestFunctionLine(t *testing.T) {
 func TestParamsNum(t *testing.T) {
 	fileName := "params_num.go"
 	file := readFile(fileName)
-	_checkerOk := checker{ParamsNum: 4}
+	_checkerOk := newChecker(`{"params_num": 4}`)
 	ps, err := _checkerOk.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -80,7 +88,7 @@ func TestParamsNum(t *testing.T) {
 		t.Fatal("expect no error")
 	}
 
-	_checkerFail := checker{ParamsNum: 3}
+	_checkerFail := newChecker(`{"params_num": 3}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 1 || ps[0].Type != ParamsNum {
 		t.Fatal("expect an error")
@@ -94,7 +102,7 @@ func TestParamsNum(t *testing.T) {
 		t.Fatal("start position is not correct")
 	}
 
-	_checkerFail = checker{ParamsNum: 2}
+	_checkerFail = newChecker(`{"params_num": 2}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 2 {
 		t.Fatal("expect 2 error")
@@ -104,7 +112,7 @@ func TestParamsNum(t *testing.T) {
 func TestResulsNum(t *testing.T) {
 	fileName := "results_num.go"
 	file := readFile(fileName)
-	_checkerOk := checker{ResultsNum: 4}
+	_checkerOk := newChecker(`{"results_num": 4}`)
 	ps, err := _checkerOk.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -113,7 +121,7 @@ func TestResulsNum(t *testing.T) {
 		t.Fatal("expect no error")
 	}
 
-	_checkerFail := checker{ResultsNum: 3}
+	_checkerFail := newChecker(`{"results_num": 3}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 1 || ps[0].Type != ResultsNum {
 		t.Fatal("expect an error")
@@ -127,7 +135,7 @@ func TestResulsNum(t *testing.T) {
 		t.Fatal("start position is not correct")
 	}
 
-	_checkerFail = checker{ResultsNum: 2}
+	_checkerFail = newChecker(`{"results_num": 2}`)
 	ps, _ = _checkerFail.Check(fileName, file)
 	if len(ps) != 2 {
 		t.Fatal("expect 2 error")
@@ -137,7 +145,7 @@ func TestResulsNum(t *testing.T) {
 func TestFormated(t *testing.T) {
 	fileName := "formated.go"
 	file := readFile(fileName)
-	_checker := checker{Formated: true}
+	_checker := newChecker(`{"formated": true}`)
 	ps, err := _checker.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -164,7 +172,7 @@ func TestFormated(t *testing.T) {
 func TestPackageName(t *testing.T) {
 	fileName := "caps_pkg.go"
 	file := readFile(fileName)
-	_checker := checker{PackageName: false}
+	_checker := newChecker(`{"pkg_name": false}`)
 	ps, err := _checker.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -184,7 +192,7 @@ func TestPackageName(t *testing.T) {
 
 	fileName = "caps_pkg.go"
 	file = readFile(fileName)
-	_checkerFail := checker{PackageName: true}
+	_checkerFail := newChecker(`{"pkg_name": true}`)
 	ps, err = _checkerFail.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -206,7 +214,7 @@ func TestPackageName(t *testing.T) {
 func TestCamelName(t *testing.T) {
 	fileName := "underscore_name.go"
 	file := readFile(fileName)
-	_checker := checker{CamelName: false}
+	_checker := newChecker(`{"camel_name": false}`)
 	ps, err := _checker.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -214,7 +222,7 @@ func TestCamelName(t *testing.T) {
 	if len(ps) != 0 {
 		t.Fatal("expect no error")
 	}
-	_checkerFail := checker{CamelName: true}
+	_checkerFail := newChecker(`{"camel_name": true}`)
 	ps, err = _checkerFail.Check(fileName, file)
 	if err != nil {
 		t.Fatal(err)
@@ -232,3 +240,108 @@ func TestCamelName(t *testing.T) {
 		t.Fatal("expect no error")
 	}
 }
+
+func TestMaxIndent(t *testing.T) {
+	fileName := "max_indent.go"
+	file := readFile(fileName)
+	_checkerOk := newChecker(`{"max_indent": 4}`)
+	ps, err := _checkerOk.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 0 {
+		t.Fatal("expect no error")
+	}
+
+	_checkerFail := newChecker(`{"max_indent": 3}`)
+	ps, _ = _checkerFail.Check(fileName, file)
+	if len(ps) != 2 || ps[0].Type != MaxIndent {
+		t.Fatal("expect 2 error")
+	}
+
+	if ps[0].Position.Line != 12 {
+		t.Fatal("start position is not correct")
+	}
+
+	if ps[1].Position.Line != 22 {
+		t.Fatal("func literal position is not correct")
+	}
+}
+
+func TestFuncComment(t *testing.T) {
+	fileName := "func_comment.go"
+	file := readFile(fileName)
+	_checker := newChecker(`{"func_comment": false}`)
+	ps, err := _checker.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 0 {
+		t.Fatal("expect no error")
+	}
+
+	_checkerFail := newChecker(`{"func_comment": true}`)
+	ps, err = _checkerFail.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := []int{7, 11, 24, 45, 46, 50}
+	if len(ps) != len(lines) {
+		t.Fatal("expect 6 error but ", len(ps))
+	}
+	for i, p := range ps {
+		if p.Type != FuncComment || p.Position.Line != lines[i] {
+			t.Fatal("unexpected problem", p.Position, p.Description)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	files := map[string][]byte{
+		"parse_error.go": readFile("parse_error.go"),
+		"fileline.go":    readFile("fileline.go"),
+	}
+	_checker := newChecker(`{"file_line": 8, "formated": true}`)
+	ps, err := _checker.CheckPackage(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[0].Type != ParseError || ps[1].Type != FileLine {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	if ps[0].Position.Filename != "parse_error.go" || ps[0].Position.Line != 5 {
+		t.Fatal("parse error position is not correct")
+	}
+	if !_checker.IsFatal(&ps[0]) {
+		t.Fatal("expect parse error is fatal")
+	}
+}
+
+func TestLineDirective(t *testing.T) {
+	fileName := "line_directive.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"camel_name": true}`).Check(fileName, file)
+	if len(ps) != 1 || ps[0].Position.Filename != fileName || ps[0].Position.Line != 4 {
+		t.Fatal("expect the problem at the line of the file", ps)
+	}
+}
+
+func TestTests(t *testing.T) {
+	fileName := "helper_test.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"func_line": 3, "camel_name": true}`).Check(fileName, file)
+	if len(ps) != 1 || ps[0].Type != CamelName || ps[0].Position.Line != 12 {
+		t.Fatal("expect only the helper name checked in tests", ps)
+	}
+
+	config := `{"func_line": 3, "params_num": 1, "camel_name": true, "tests": {"func_line": 4, "camel_name": false}}`
+	ps, _ = newChecker(config).Check(fileName, file)
+	if len(ps) != 1 || ps[0].Type != FunctionLine || ps[0].Position.Line != 12 {
+		t.Fatal("expect tests checked by the rules of tests block", ps)
+	}
+
+	_, err := New([]byte(`{"tests": {"func_line": -1}}`))
+	if e, ok := err.(*ConfigError); !ok || e.Key != "tests.func_line" {
+		t.Fatal("expect error of tests config", err)
+	}
+}
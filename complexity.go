@@ -0,0 +1,276 @@
+package checkstyle
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+)
+
+type cyclomaticRule struct {
+	limitConfig
+}
+
+func (*cyclomaticRule) Name() string      { return string(Cyclomatic) }
+func (*cyclomaticRule) Type() ProblemType { return Cyclomatic }
+
+func (r *cyclomaticRule) CheckNode(f *File, node ast.Node) {
+	name, body := funcBody(node)
+	if body == nil {
+		return
+	}
+	complexity := cyclomatic(body)
+	if complexity > r.limit {
+		desc := "func " + name + " cyclomatic complexity " + strconv.Itoa(complexity) +
+			" more than " + strconv.Itoa(r.limit)
+		data := map[string]interface{}{"func": name, "complexity": complexity, "limit": r.limit}
+		f.ReportData(node.Pos(), Cyclomatic, desc, data)
+	}
+}
+
+// funcBody returns the name and body of a function declaration or literal.
+func funcBody(node ast.Node) (string, *ast.BlockStmt) {
+	switch fn := node.(type) {
+	case *ast.FuncDecl:
+		return fn.Name.Name + "()", fn.Body
+	case *ast.FuncLit:
+		return "literal", fn.Body
+	}
+	return "", nil
+}
+
+// cyclomatic returns the McCabe complexity of the function body, which is
+// one plus the branches. The function literals in it are not counted.
+func cyclomatic(body *ast.BlockStmt) int {
+	complexity := 1
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if n.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+// cognitiveTop is the count of the statements listed in the description.
+const cognitiveTop = 3
+
+type cognitiveRule struct {
+	limitConfig
+}
+
+func (*cognitiveRule) Name() string      { return string(Cognitive) }
+func (*cognitiveRule) Type() ProblemType { return Cognitive }
+
+func (r *cognitiveRule) CheckNode(f *File, node ast.Node) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok || funcDecl.Body == nil {
+		return
+	}
+	v := &cognitiveVisitor{fset: f.Fset, info: f.TypesInfo, fn: funcDecl}
+	v.walk(funcDecl.Body)
+	complexity := 0
+	for _, item := range v.items {
+		complexity += item.increment
+	}
+	if complexity <= r.limit {
+		return
+	}
+	sort.SliceStable(v.items, func(i, j int) bool { return v.items[i].increment > v.items[j].increment })
+	top := v.items
+	if len(top) > cognitiveTop {
+		top = top[:cognitiveTop]
+	}
+	desc := "func " + funcDecl.Name.Name + "() cognitive complexity " + strconv.Itoa(complexity) +
+		" more than " + strconv.Itoa(r.limit) + ", most by"
+	var topData []map[string]interface{}
+	for i, item := range top {
+		if i != 0 {
+			desc += ","
+		}
+		desc += " " + item.kind + " at line " + strconv.Itoa(item.line) + " +" + strconv.Itoa(item.increment)
+		topData = append(topData, map[string]interface{}{"line": item.line, "kind": item.kind, "increment": item.increment})
+	}
+	data := map[string]interface{}{"func": funcDecl.Name.Name + "()", "complexity": complexity, "limit": r.limit, "top": topData}
+	f.ReportData(funcDecl.Pos(), Cognitive, desc, data)
+}
+
+// cognitiveItem is a statement or expression increasing the cognitive
+// complexity.
+type cognitiveItem struct {
+	line      int
+	kind      string
+	increment int
+}
+
+// cognitiveVisitor computes the cognitive complexity of a function. The
+// branches and loops increase it by one plus their nesting, the else,
+// the sequences of logical operators, the jumps to labels and the
+// recursive calls increase it by one.
+type cognitiveVisitor struct {
+	fset    *token.FileSet
+	info    *types.Info
+	fn      *ast.FuncDecl
+	nesting int
+	items   []cognitiveItem
+}
+
+func (v *cognitiveVisitor) add(pos token.Pos, kind string, increment int) {
+	v.items = append(v.items, cognitiveItem{line: v.fset.Position(pos).Line, kind: kind, increment: increment})
+}
+
+func (v *cognitiveVisitor) walk(node ast.Node) {
+	if node != nil {
+		ast.Inspect(node, v.visit)
+	}
+}
+
+func (v *cognitiveVisitor) nested(body ast.Node) {
+	v.nesting++
+	v.walk(body)
+	v.nesting--
+}
+
+func (v *cognitiveVisitor) visit(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.IfStmt:
+		v.add(n.Pos(), "if", 1+v.nesting)
+		v.walkIf(n)
+	case *ast.ForStmt:
+		v.add(n.Pos(), "for", 1+v.nesting)
+		v.walk(n.Init)
+		v.walk(n.Cond)
+		v.walk(n.Post)
+		v.nested(n.Body)
+	case *ast.RangeStmt:
+		v.add(n.Pos(), "range", 1+v.nesting)
+		v.walk(n.X)
+		v.nested(n.Body)
+	case *ast.SwitchStmt:
+		v.add(n.Pos(), "switch", 1+v.nesting)
+		v.walk(n.Init)
+		v.walk(n.Tag)
+		v.nested(n.Body)
+	case *ast.TypeSwitchStmt:
+		v.add(n.Pos(), "switch", 1+v.nesting)
+		v.walk(n.Init)
+		v.nested(n.Body)
+	case *ast.SelectStmt:
+		v.add(n.Pos(), "select", 1+v.nesting)
+		v.nested(n.Body)
+	case *ast.FuncLit:
+		v.nested(n.Body)
+	case *ast.BinaryExpr:
+		if n.Op != token.LAND && n.Op != token.LOR {
+			return true
+		}
+		var ops []token.Token
+		v.walkLogical(n, &ops)
+		v.add(n.Pos(), "logical operators", logicalSequences(ops))
+	default:
+		return v.visitLeaf(node)
+	}
+	return false
+}
+
+func (v *cognitiveVisitor) visitLeaf(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.BranchStmt:
+		if n.Label != nil {
+			v.add(n.Pos(), n.Tok.String()+" label", 1)
+		}
+	case *ast.CallExpr:
+		if v.isRecursion(n) {
+			v.add(n.Pos(), "recursion", 1)
+		}
+	}
+	return true
+}
+
+func (v *cognitiveVisitor) walkIf(n *ast.IfStmt) {
+	v.walk(n.Init)
+	v.walk(n.Cond)
+	v.nested(n.Body)
+	switch e := n.Else.(type) {
+	case *ast.IfStmt:
+		v.add(e.Pos(), "else if", 1)
+		v.walkIf(e)
+	case *ast.BlockStmt:
+		v.add(e.Pos(), "else", 1)
+		v.nested(e)
+	}
+}
+
+// walkLogical collects the operators of a logical expression in order, and
+// walks the operands.
+func (v *cognitiveVisitor) walkLogical(expr ast.Expr, ops *[]token.Token) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		v.walkLogical(e.X, ops)
+		return
+	case *ast.BinaryExpr:
+		if e.Op == token.LAND || e.Op == token.LOR {
+			v.walkLogical(e.X, ops)
+			*ops = append(*ops, e.Op)
+			v.walkLogical(e.Y, ops)
+			return
+		}
+	}
+	v.walk(expr)
+}
+
+// logicalSequences counts the sequences of the same operator, a && b || c
+// has 2 sequences.
+func logicalSequences(ops []token.Token) int {
+	count := 0
+	for i, op := range ops {
+		if i == 0 || op != ops[i-1] {
+			count++
+		}
+	}
+	return count
+}
+
+// isRecursion reports whether call is the function itself, or the method
+// on the receiver. The calls of the method on other values of the type are
+// found only if type_check is enabled.
+func (v *cognitiveVisitor) isRecursion(call *ast.CallExpr) bool {
+	var id *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	}
+	if id == nil || id.Name != v.fn.Name.Name {
+		return false
+	}
+	if v.info != nil && v.info.Defs[v.fn.Name] != nil {
+		return v.info.Uses[id] == v.info.Defs[v.fn.Name]
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return v.fn.Recv == nil
+	}
+	if v.fn.Recv == nil || len(v.fn.Recv.List) == 0 || len(v.fn.Recv.List[0].Names) == 0 {
+		return false
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	return ok && recv.Name == v.fn.Recv.List[0].Names[0].Name
+}
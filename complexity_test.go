@@ -0,0 +1,56 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestCyclomatic(t *testing.T) {
+	fileName := "complexity.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"cyclomatic": 2}`).Check(fileName, file)
+	if len(ps) != 2 {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	if ps[0].Position.Line != 7 || ps[0].Description != "func complex() cyclomatic complexity 7 more than 2" {
+		t.Fatal("unexpected problem", ps[0].Position, ps[0].Description)
+	}
+	if ps[0].Data["complexity"] != 7 || ps[0].Data["func"] != "complex()" || ps[0].Data["limit"] != 2 {
+		t.Fatal("unexpected data", ps[0].Data)
+	}
+	if ps[1].Position.Line != 25 || ps[1].Data["complexity"] != 3 {
+		t.Fatal("expect literal counted alone", ps[1].Position, ps[1].Data)
+	}
+
+	ps, _ = newChecker(`{"cyclomatic": 7}`).Check(fileName, file)
+	if len(ps) != 0 {
+		t.Fatal("expect no error but ", len(ps))
+	}
+}
+
+func TestCognitive(t *testing.T) {
+	fileName := "cognitive.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"cognitive": 4}`).Check(fileName, file)
+	if len(ps) != 1 {
+		t.Fatal("expect 1 error but ", len(ps))
+	}
+	expect := "func sum() cognitive complexity 10 more than 4, most by if at line 29 +3, range at line 28 +2, logical operators at line 29 +2"
+	if ps[0].Position.Line != 26 || ps[0].Description != expect {
+		t.Fatal("unexpected problem", ps[0].Position, ps[0].Description)
+	}
+	if ps[0].Data["complexity"] != 10 || len(ps[0].Data["top"].([]map[string]interface{})) != cognitiveTop {
+		t.Fatal("unexpected data", ps[0].Data)
+	}
+
+	// the delegation to t.closer.Close is not recursion
+	ps, _ = newChecker(`{"cognitive": 1}`).Check(fileName, file)
+	if len(ps) != 2 || ps[0].Position.Line != 19 || ps[0].Data["complexity"] != 2 {
+		t.Fatal("expect only the calls on the receiver counted", ps)
+	}
+
+	// the calls on the fields of the same type are found by type check
+	ps, _ = newChecker(`{"cognitive": 3, "type_check": true}`).Check(fileName, file)
+	if len(ps) != 2 || ps[0].Data["complexity"] != 4 {
+		t.Fatal("expect recursion counted", ps)
+	}
+}
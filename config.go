@@ -0,0 +1,199 @@
+package checkstyle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseConfig converts a JSON, YAML or TOML config to JSON. The format is
+// detected by the extension of fileName, or by the content if fileName
+// has no known extension.
+func ParseConfig(fileName string, data []byte) ([]byte, error) {
+	m, err := decodeConfig(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+func configFormat(fileName string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return "json"
+	}
+	var m map[string]interface{}
+	if _, err := toml.Decode(string(data), &m); err == nil {
+		return "toml"
+	}
+	return "yaml"
+}
+
+// decodeConfig decodes the config of any format, the empty or whitespace
+// only data is an empty config.
+func decodeConfig(fileName string, data []byte) (m map[string]interface{}, err error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	switch configFormat(fileName, data) {
+	case "json":
+		err = json.Unmarshal(data, &m)
+	case "toml":
+		_, err = toml.Decode(string(data), &m)
+	case "yaml":
+		err = yaml.Unmarshal(data, &m)
+	}
+	return m, err
+}
+
+// MergeConfig returns the config overlay merged onto base as JSON. The
+// values of overlay take precedence, and the nested objects are merged
+// recursively.
+func MergeConfig(base, overlay []byte) ([]byte, error) {
+	baseMap, err := decodeConfig("", base)
+	if err != nil {
+		return nil, err
+	}
+	overlayMap, err := decodeConfig("", overlay)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeMap(baseMap, overlayMap, false))
+}
+
+// listMergeKeys are the lists merged by extends, instead of overridden.
+var listMergeKeys = map[string]bool{"fatal": true, "ignore": true}
+
+func mergeMap(base, overlay map[string]interface{}, mergeLists bool) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		baseValue, ok1 := merged[k].(map[string]interface{})
+		overlayValue, ok2 := v.(map[string]interface{})
+		baseList, ok3 := merged[k].([]interface{})
+		overlayList, ok4 := v.([]interface{})
+		if ok1 && ok2 {
+			merged[k] = mergeMap(baseValue, overlayValue, mergeLists)
+		} else if mergeLists && listMergeKeys[k] && ok3 && ok4 {
+			merged[k] = mergeList(baseList, overlayList)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func mergeList(base, overlay []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, v := range overlay {
+		found := false
+		for _, v2 := range base {
+			found = found || v2 == v
+		}
+		if !found {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
+
+// LoadConfig reads the config file, and resolves its extends relative to
+// the directory of the file. The result is JSON.
+func LoadConfig(fileName string) ([]byte, error) {
+	m, err := loadExtends(fileName, ".", map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// ResolveConfig resolves the extends of config relative to dir, and
+// returns the config as JSON. The extends key is a preset name or a file
+// path, or a list of them, the later one takes precedence. The limits of
+// the extending config override the extended ones, and the lists of fatal
+// and ignore are merged. The file patterns of imports are resolved against
+// dir, or the directory of the extended file declaring them.
+func ResolveConfig(config []byte, dir string) ([]byte, error) {
+	m, err := resolveExtends("", config, dir, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+func resolveExtends(fileName string, data []byte, dir string, visited map[string]bool) (map[string]interface{}, error) {
+	m, err := decodeConfig(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	var extends []string
+	switch v := m["extends"].(type) {
+	case nil:
+	case string:
+		extends = []string{v}
+	case []interface{}:
+		for _, v2 := range v {
+			name, ok := v2.(string)
+			if !ok {
+				return nil, &ConfigError{Key: "extends", Err: errors.New("expect string list")}
+			}
+			extends = append(extends, name)
+		}
+	default:
+		return nil, &ConfigError{Key: "extends", Err: errors.New("expect string or string list")}
+	}
+	delete(m, "extends")
+	resolveImportFiles(m, dir)
+
+	merged := map[string]interface{}{}
+	for _, name := range extends {
+		base, err := loadExtends(name, dir, visited)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeMap(merged, base, true)
+	}
+	return mergeMap(merged, m, true), nil
+}
+
+// loadExtends loads the preset or the config file of name.
+func loadExtends(name, dir string, visited map[string]bool) (map[string]interface{}, error) {
+	key := "preset " + name
+	data, ok := Preset(name)
+	if !ok {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		key, _ = filepath.Abs(name)
+	}
+	if visited[key] {
+		return nil, &ConfigError{Key: "extends", Err: errors.New("cycle at " + key)}
+	}
+	visited[key] = true
+	defer delete(visited, key)
+
+	if !ok {
+		var err error
+		data, err = ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Dir(name)
+	}
+	return resolveExtends(name, data, dir, visited)
+}
@@ -0,0 +1,104 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeConfig(t *testing.T) {
+	base := `{"func_line": 50, "camel_name": true, "fatal": ["formated"], "severity": {"pkg_name": "info", "camel_name": "error"}}`
+	overlay := `{"func_line": 80, "fatal": ["func_line"], "severity": {"camel_name": "warning"}}`
+	merged, err := MergeConfig([]byte(base), []byte(overlay))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, expect map[string]interface{}
+	json.Unmarshal(merged, &got)
+	json.Unmarshal([]byte(`{
+		"func_line": 80,
+		"camel_name": true,
+		"fatal": ["func_line"],
+		"severity": {"pkg_name": "info", "camel_name": "warning"}
+	}`), &expect)
+	if !reflect.DeepEqual(got, expect) {
+		t.Fatal("unexpected merged config", string(merged))
+	}
+
+	if _, err = MergeConfig([]byte(base), []byte(`[]`)); err == nil {
+		t.Fatal("expect error for non object config")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	expect := map[string]interface{}{
+		"func_line":  float64(50),
+		"camel_name": true,
+		"fatal":      []interface{}{"formated"},
+		"severity":   map[string]interface{}{"pkg_name": "info"},
+	}
+	configs := map[string]string{
+		"a.json": `{"func_line": 50, "camel_name": true, "fatal": ["formated"], "severity": {"pkg_name": "info"}}`,
+		"a.yaml": "# function line count limit\nfunc_line: 50\ncamel_name: true\nfatal:\n  - formated\nseverity:\n  pkg_name: info\n",
+		"a.toml": "# function line count limit\nfunc_line = 50\ncamel_name = true\nfatal = [\"formated\"]\n[severity]\npkg_name = \"info\"\n",
+	}
+	for fileName, config := range configs {
+		for _, name := range []string{fileName, ".gostyle"} {
+			data, err := ParseConfig(name, []byte(config))
+			if err != nil {
+				t.Fatal(name, err)
+			}
+			var got map[string]interface{}
+			json.Unmarshal(data, &got)
+			if !reflect.DeepEqual(got, expect) {
+				t.Fatal("unexpected config of", fileName, string(data))
+			}
+		}
+	}
+
+	_checker := newChecker(configs["a.yaml"])
+	if _checker.Severity(PackageName) != SeverityInfo {
+		t.Fatal("expect yaml config accepted by New")
+	}
+	if _, err := ParseConfig("a.yaml", []byte("func_line: [")); err == nil {
+		t.Fatal("expect yaml syntax error")
+	}
+	for _, name := range []string{".gostyle", "a.json", "a.yaml"} {
+		if data, err := ParseConfig(name, []byte(" \n")); err != nil || string(data) != "{}" {
+			t.Fatal("expect empty config of", name, string(data), err)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	data, err := LoadConfig("testdata/extends/child.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(data, &got)
+	if got["func_line"] != float64(80) || got["params_num"] != float64(6) || got["file_line"] != float64(200) {
+		t.Fatal("expect limits overridden", string(data))
+	}
+	if _, ok := got["extends"]; ok {
+		t.Fatal("expect extends resolved", string(data))
+	}
+	fatal := []interface{}{"formated", "func_line", "params_num"}
+	if !reflect.DeepEqual(got["fatal"], fatal) {
+		t.Fatal("expect fatal merged", got["fatal"])
+	}
+	ignore := []interface{}{"tmp/*", "src/tmp.go", "gen/*"}
+	if !reflect.DeepEqual(got["ignore"], ignore) {
+		t.Fatal("expect ignore merged", got["ignore"])
+	}
+
+	if _, err = LoadConfig("testdata/extends/cycle.yaml"); err == nil {
+		t.Fatal("expect extends cycle error")
+	}
+	if _, err = ResolveConfig([]byte(`{"extends": "missing.json"}`), "testdata/extends"); err == nil {
+		t.Fatal("expect missing extends error")
+	}
+	if _, err = ResolveConfig([]byte(`{"extends": 1}`), "."); err == nil {
+		t.Fatal("expect extends type error")
+	}
+}
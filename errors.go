@@ -0,0 +1,158 @@
+package checkstyle
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errorStringRule checks the literal messages of errors.New and
+// fmt.Errorf, which should not be capitalized or end with punctuation.
+type errorStringRule struct {
+	switchConfig
+}
+
+func (*errorStringRule) Name() string      { return string(ErrorString) }
+func (*errorStringRule) Type() ProblemType { return ErrorString }
+
+func (*errorStringRule) CheckNode(f *File, node ast.Node) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || !isErrorCall(call) || len(call.Args) == 0 {
+		return
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil || s == "" {
+		return
+	}
+	offset := f.Fset.Position(lit.Pos()).Offset
+	if first, size := utf8.DecodeRuneInString(s); isCapitalized(s) {
+		var fix []TextEdit
+		if unicode.IsUpper(first) && len(lit.Value) > size && lit.Value[1:1+size] == string(first) {
+			// the first rune is not escaped in the literal
+			fix = []TextEdit{{Offset: offset + 1, End: offset + 1 + size, NewText: []byte(string(unicode.ToLower(first)))}}
+		}
+		f.ReportFix(lit.Pos(), ErrorString, "error string should not be capitalized: "+lit.Value, fix)
+	}
+	if last := s[len(s)-1]; last == '.' || last == ':' || last == '!' || last == '\n' {
+		var fix []TextEdit
+		if end := offset + len(lit.Value) - 1; lit.Value[len(lit.Value)-2] == last {
+			fix = []TextEdit{{Offset: end - 1, End: end}}
+		}
+		f.ReportFix(lit.Pos(), ErrorString, "error string should not end with punctuation or a newline: "+lit.Value, fix)
+	}
+}
+
+// isErrorCall reports whether call is errors.New or fmt.Errorf.
+func isErrorCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && (pkg.Name == "errors" && sel.Sel.Name == "New" || pkg.Name == "fmt" && sel.Sel.Name == "Errorf")
+}
+
+// isCapitalized reports whether the first word of s is capitalized, the
+// words with other capital letters like URL are allowed.
+func isCapitalized(s string) bool {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return false
+	}
+	word := words[0]
+	first, size := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(first) && strings.ToLower(word[size:]) == word[size:]
+}
+
+// errorNameRule checks the names of the sentinel error variables, which
+// should be ErrXxx or errXxx, and the error types, which should be XxxError.
+type errorNameRule struct {
+	switchConfig
+}
+
+func (*errorNameRule) Name() string      { return string(ErrorName) }
+func (*errorNameRule) Type() ProblemType { return ErrorName }
+
+func (*errorNameRule) CheckPackage(pkg *Package) {
+	errorTypes := map[string]bool{}
+	for _, f := range pkg.Files {
+		for _, decl := range f.AST.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && isErrorMethod(funcDecl) {
+				errorTypes[receiverTypeName(funcDecl.Recv)] = true
+			}
+		}
+	}
+	for _, f := range pkg.Files {
+		nameVisitor(func(id *ast.Ident, kind string, local bool) {
+			if kind == "type" && errorTypes[id.Name] && !strings.HasSuffix(id.Name, "Error") {
+				desc := "error type " + id.Name + " should have name of the form " + errorTypeName(id.Name)
+				f.Report(id.Pos(), ErrorName, desc)
+			} else if kind == "var" && !local && isErrorValue(id) && !isErrorVarName(id.Name) {
+				desc := "error var " + id.Name + " should have name of the form " + errorVarName(id.Name)
+				f.Report(id.Pos(), ErrorName, desc)
+			}
+		}).walkFile(f.AST)
+	}
+}
+
+// isErrorMethod reports whether funcDecl is the method Error() string.
+func isErrorMethod(funcDecl *ast.FuncDecl) bool {
+	fType := funcDecl.Type
+	if funcDecl.Recv == nil || funcDecl.Name.Name != "Error" || fType.Params.NumFields() != 0 ||
+		fType.Results.NumFields() != 1 {
+		return false
+	}
+	id, ok := fType.Results.List[0].Type.(*ast.Ident)
+	return ok && id.Name == "string"
+}
+
+// isErrorValue reports whether the var is declared with the value of
+// errors.New or fmt.Errorf.
+func isErrorValue(id *ast.Ident) bool {
+	if id.Obj == nil {
+		return false
+	}
+	spec, ok := id.Obj.Decl.(*ast.ValueSpec)
+	if !ok || len(spec.Values) != len(spec.Names) {
+		return false
+	}
+	for i, name := range spec.Names {
+		if name == id {
+			call, ok := spec.Values[i].(*ast.CallExpr)
+			return ok && isErrorCall(call)
+		}
+	}
+	return false
+}
+
+func isErrorVarName(name string) bool {
+	if name == "_" {
+		return true
+	}
+	prefix := "err"
+	if ast.IsExported(name) {
+		prefix = "Err"
+	}
+	return strings.HasPrefix(name, prefix)
+}
+
+func errorVarName(name string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(name, "Error"), "Err")
+	r, size := utf8.DecodeRuneInString(base)
+	base = string(unicode.ToUpper(r)) + base[size:]
+	if ast.IsExported(name) {
+		return "Err" + base
+	}
+	return "err" + base
+}
+
+func errorTypeName(name string) string {
+	return strings.TrimSuffix(name, "Err") + "Error"
+}
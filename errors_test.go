@@ -0,0 +1,54 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestErrorString(t *testing.T) {
+	fileName := "errors.go"
+	file := readFile(fileName)
+	_checker := newChecker(`{"error_string": true}`)
+	ps, _ := _checker.Check(fileName, file)
+	expect := []string{
+		`error string should not be capitalized: "Not found."`,
+		`error string should not end with punctuation or a newline: "Not found."`,
+		`error string should not end with punctuation or a newline: "closed:"`,
+		`error string should not end with punctuation or a newline: "\n"`,
+		`error string should not be capitalized: "\u0046oo"`,
+		`error string should not be capitalized: "Timeout of %s\n"`,
+		`error string should not end with punctuation or a newline: "Timeout of %s\n"`,
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 7 error but ", len(ps))
+	}
+	for i, p := range ps {
+		if p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Description)
+		}
+	}
+	if len(ps[3].Fix) != 0 || len(ps[4].Fix) != 0 || len(ps[6].Fix) != 0 {
+		t.Fatal("expect escaped runes not fixed")
+	}
+	ps, _ = _checker.Check(fileName, fixAll(file, ps))
+	if len(ps) != 3 {
+		t.Fatal("expect 3 error after fix but ", len(ps))
+	}
+}
+
+func TestErrorName(t *testing.T) {
+	fileName := "errors.go"
+	ps, _ := newChecker(`{"error_name": true}`).Check(fileName, readFile(fileName))
+	expect := []string{
+		"error var NotFoundErr should have name of the form ErrNotFound",
+		"error var invalidError should have name of the form errInvalid",
+		"error type timeout should have name of the form timeoutError",
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 3 error but ", len(ps))
+	}
+	for i, p := range ps {
+		if p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Description)
+		}
+	}
+}
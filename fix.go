@@ -0,0 +1,37 @@
+package checkstyle
+
+import (
+	"bytes"
+	"sort"
+)
+
+// TextEdit replaces the bytes of a file between Offset and End with NewText.
+type TextEdit struct {
+	Offset  int
+	End     int
+	NewText []byte
+}
+
+// ApplyEdits returns src with the edits applied. The edits overlapping
+// a previous one are skipped, they could be applied by checking the
+// result again.
+func ApplyEdits(src []byte, edits []TextEdit) []byte {
+	sorted := make([]TextEdit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	var buf bytes.Buffer
+	last := 0
+	for _, edit := range sorted {
+		if edit.Offset < last || edit.End > len(src) || edit.End < edit.Offset {
+			continue
+		}
+		buf.Write(src[last:edit.Offset])
+		buf.Write(edit.NewText)
+		last = edit.End
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
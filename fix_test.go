@@ -0,0 +1,61 @@
+package checkstyle
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestApplyEdits(t *testing.T) {
+	src := []byte("hello world")
+	edits := []TextEdit{
+		{Offset: 6, End: 11, NewText: []byte("go")},
+		{Offset: 0, End: 5, NewText: []byte("hi")},
+		{Offset: 4, End: 8, NewText: []byte("overlap")},
+	}
+	if out := string(ApplyEdits(src, edits)); out != "hi go" {
+		t.Fatal("unexpected result", out)
+	}
+}
+
+func fixAll(src []byte, ps []Problem) []byte {
+	var edits []TextEdit
+	for _, p := range ps {
+		edits = append(edits, p.Fix...)
+	}
+	return ApplyEdits(src, edits)
+}
+
+func TestFormatedFix(t *testing.T) {
+	fileName := "unformated.go"
+	file := readFile(fileName)
+	_checker := newChecker(`{"formated": true}`)
+	ps, _ := _checker.Check(fileName, file)
+	if len(ps) != 1 || len(ps[0].Fix) != 1 {
+		t.Fatal("expect a fix")
+	}
+	ps, _ = _checker.Check(fileName, fixAll(file, ps))
+	if len(ps) != 0 {
+		t.Fatal("expect no error after fix")
+	}
+}
+
+func TestCamelNameFix(t *testing.T) {
+	fileName := "camel_fix.go"
+	file := readFile(fileName)
+	_checker := newChecker(`{"camel_name": true}`)
+	ps, _ := _checker.Check(fileName, file)
+	if len(ps) != 6 {
+		t.Fatal("expect 6 error but ", len(ps))
+	}
+	fixed := fixAll(file, ps)
+	if _, err := parser.ParseFile(token.NewFileSet(), fileName, fixed, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, _ = _checker.Check(fileName, fixed)
+	// struct field is not local, and X_Y is also used as a field name
+	if len(ps) != 2 || ps[0].Position.Line != 6 || ps[1].Position.Line != 13 {
+		t.Fatal("expect 2 error left but ", len(ps))
+	}
+}
@@ -0,0 +1,85 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+)
+
+// generatedMode is how the checker treats the generated files.
+type generatedMode int
+
+const (
+	// generatedSkip skips the generated files, it is the default.
+	generatedSkip generatedMode = iota
+	// generatedWarn reports the problems of the generated files as warnings
+	// at most, without fixes.
+	generatedWarn
+	generatedCheck
+)
+
+var generatedModeNames = []string{"skip", "warn", "check"}
+
+func (m *generatedMode) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	for i, v := range generatedModeNames {
+		if v == name {
+			*m = generatedMode(i)
+			return nil
+		}
+	}
+	return errors.New("unknown generated mode " + name + ", expect skip, warn or check")
+}
+
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated reports whether the file has the comment of generated code
+// before the package clause.
+func isGenerated(f *ast.File) bool {
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if generatedComment.MatchString(c.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsGenerated reports whether src is generated code, which has a comment
+// like "// Code generated by protoc-gen-go. DO NOT EDIT." before the
+// package clause.
+func IsGenerated(src []byte) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.PackageClauseOnly|parser.ParseComments)
+	return err == nil && isGenerated(f)
+}
+
+// generatedProblems applies the generated mode to the problems of f.
+func (c *checker) generatedProblems(f *File) []Problem {
+	if !f.Generated || c.Generated == generatedCheck {
+		return f.problems
+	} else if c.Generated == generatedSkip {
+		return nil
+	}
+	for i := range f.problems {
+		p := &f.problems[i]
+		if p.Severity == SeverityOff {
+			p.Severity = c.Severity(p.Type)
+		}
+		if p.Severity > SeverityWarning {
+			p.Severity = SeverityWarning
+		}
+		p.Fix = nil
+	}
+	return f.problems
+}
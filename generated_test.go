@@ -0,0 +1,53 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	fileName := "generated.go"
+	file := readFile(fileName)
+	if !IsGenerated(file) || IsGenerated(readFile("camel_name.go")) {
+		t.Fatal("expect generated file detected")
+	}
+
+	_checker := newChecker(`{"camel_name": true, "fatal": ["camel_name"]}`)
+	ps, _ := _checker.Check(fileName, file)
+	if len(ps) != 0 {
+		t.Fatal("expect generated file skipped", ps)
+	}
+
+	_checker = newChecker(`{"camel_name": true, "fatal": ["camel_name"], "generated": "warn"}`)
+	ps, _ = _checker.Check(fileName, file)
+	if len(ps) != 2 {
+		t.Fatal("expect 2 problems of generated file", ps)
+	}
+	for _, p := range ps {
+		if p.Severity != SeverityWarning {
+			t.Fatal("expect warning", p.Severity)
+		}
+	}
+
+	ps, _ = newChecker(`{"camel_name": true, "fatal": ["camel_name"], "generated": "check"}`).Check(fileName, file)
+	if len(ps) != 2 || ps[0].Severity != SeverityError {
+		t.Fatal("expect generated file checked", ps)
+	}
+
+	if _, err := New([]byte(`{"generated": "ignore"}`)); err == nil {
+		t.Fatal("expect unknown generated mode")
+	}
+}
+
+func TestGeneratedPackage(t *testing.T) {
+	files := map[string][]byte{
+		"a.go": readFile("receiver/a.go"),
+		"b.go": []byte("// Code generated by mockgen. DO NOT EDIT.\n\npackage receiver\n\n" +
+			"func (this *counter) Get() int { return this.n }\n\nfunc (this *counter) Set(n int) { this.n = n }\n\n" +
+			"func (this *counter) Add(n int) { this.n += n }\n"),
+	}
+	ps, _ := newChecker(`{"receiver_name": 2}`).CheckPackage(files)
+	expect := "receiver name this of method counter.Reset should be consistent with c of the other methods"
+	if len(ps) != 2 || ps[1].Position.Filename != "a.go" || ps[1].Description != expect {
+		t.Fatal("expect generated receivers not counted", ps)
+	}
+}
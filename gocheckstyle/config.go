@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/qiniu/checkstyle"
+)
+
+// configFileName is the config discovered in the directories of the
+// checked files and their parents.
+const configFileName = ".gostyle"
+
+// configLayer is a config file, the ignore patterns of a discovered config
+// are relative to its dir.
+type configLayer struct {
+	dir    string
+	conf   []byte
+	Ignore []string `json:"ignore"`
+	Root   bool     `json:"root"`
+}
+
+// styleConfig is the effective config of a directory.
+type styleConfig struct {
+	checker checkstyle.Checker
+	layers  []*configLayer
+	// Generated is the mode of the generated files, they are skipped if
+	// it is empty or skip.
+	Generated string `json:"generated"`
+}
+
+// configFinder discovers the configs of the directories, and caches the
+// configs and the checkers.
+type configFinder struct {
+	base *configLayer
+	// baseFile is the absolute path of the -config file, which is not
+	// discovered again.
+	baseFile string
+
+	layers   map[string][]*configLayer
+	configs  map[string]*styleConfig
+	checkers map[string]checkstyle.Checker
+}
+
+var finder *configFinder
+
+func newLayer(dir, fileName string) (*configLayer, error) {
+	layer := &configLayer{dir: dir}
+	var conf []byte
+	var err error
+	if fileName == "" {
+		conf, err = checkstyle.ResolveConfig([]byte(defaultConfig), ".")
+	} else {
+		conf, err = checkstyle.LoadConfig(fileName)
+	}
+	if err == nil {
+		layer.conf = conf
+		err = json.Unmarshal(conf, layer)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config %v fail %v", fileName, err)
+	}
+	return layer, nil
+}
+
+// newConfigFinder returns the finder with the base config file, or the
+// default config if configFile is empty.
+func newConfigFinder(configFile string) (*configFinder, error) {
+	base, err := newLayer("", configFile)
+	if err != nil {
+		return nil, err
+	}
+	c := &configFinder{
+		base:     base,
+		layers:   map[string][]*configLayer{},
+		configs:  map[string]*styleConfig{},
+		checkers: map[string]checkstyle.Checker{},
+	}
+	if configFile != "" {
+		c.baseFile, _ = filepath.Abs(configFile)
+	}
+	return c, nil
+}
+
+func loadConfig() {
+	var err error
+	finder, err = newConfigFinder(*config)
+	if err != nil {
+		log.Fatalf("Load config fail %v\n", err)
+	}
+}
+
+func (c *configFinder) readLayer(dir string) (*configLayer, error) {
+	fileName := filepath.Join(dir, configFileName)
+	if fileName == c.baseFile {
+		return nil, nil
+	}
+	if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return newLayer(dir, fileName)
+}
+
+// findLayers returns the configs discovered from the absolute dir up to the
+// file system root or a config with root: true, the nearest is the last.
+func (c *configFinder) findLayers(dir string) ([]*configLayer, error) {
+	if layers, ok := c.layers[dir]; ok {
+		return layers, nil
+	}
+	var layers []*configLayer
+	layer, err := c.readLayer(dir)
+	if err != nil {
+		return nil, err
+	}
+	if parent := filepath.Dir(dir); (layer == nil || !layer.Root) && parent != dir {
+		layers, err = c.findLayers(parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if layer != nil {
+		layers = append(layers, layer)
+	}
+	c.layers[dir] = layers
+	return layers, nil
+}
+
+// find returns the config of dir, which is the base config merged with the
+// discovered configs, the nearest wins.
+func (c *configFinder) find(dir string) (*styleConfig, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if style, ok := c.configs[absDir]; ok {
+		return style, nil
+	}
+	layers, err := c.findLayers(absDir)
+	if err != nil {
+		return nil, err
+	}
+	style := &styleConfig{layers: append([]*configLayer{c.base}, layers...)}
+	conf := c.base.conf
+	for _, layer := range layers {
+		conf, err = checkstyle.MergeConfig(conf, layer.conf)
+		if err != nil {
+			return nil, fmt.Errorf("merge config %v fail %v", filepath.Join(layer.dir, configFileName), err)
+		}
+	}
+	if err = json.Unmarshal(conf, style); err != nil {
+		return nil, err
+	}
+	style.checker = c.checkers[string(conf)]
+	if style.checker == nil {
+		style.checker, err = checkstyle.New(conf)
+		if err != nil {
+			return nil, err
+		}
+		c.checkers[string(conf)] = style.checker
+	}
+	c.configs[absDir] = style
+	return style, nil
+}
+
+func findConfig(dir string) *styleConfig {
+	style, err := finder.find(dir)
+	if err != nil {
+		log.Fatalf("Find config of %v fail %v\n", dir, err)
+	}
+	return style
+}
+
+// skips reports whether the checker skips src, which is generated code.
+func (c *styleConfig) skips(src []byte) bool {
+	return (c.Generated == "" || c.Generated == "skip") && checkstyle.IsGenerated(src)
+}
+
+// isIgnore reports whether path matches the ignore patterns of the configs.
+func (c *styleConfig) isIgnore(path string) bool {
+	absPath, _ := filepath.Abs(path)
+	for _, layer := range c.layers {
+		name := path
+		if layer.dir != "" {
+			name, _ = filepath.Rel(layer.dir, absPath)
+		}
+		for _, v := range layer.Ignore {
+			if ok, _ := filepath.Match(v, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateConfigs implements the validate-config subcommand, which checks
+// the config files strictly.
+func validateConfigs(fileNames []string) int {
+	if len(fileNames) == 0 && *config != "" {
+		fileNames = []string{*config}
+	} else if len(fileNames) == 0 {
+		fileNames = []string{configFileName}
+	}
+	code := 0
+	for _, fileName := range fileNames {
+		conf, err := ioutil.ReadFile(fileName)
+		if err == nil {
+			conf, err = checkstyle.ParseConfig(fileName, conf)
+		}
+		if err == nil {
+			// the extended configs should exist
+			_, err = checkstyle.LoadConfig(fileName)
+		}
+		if err != nil {
+			log.Printf("%v: %v\n", fileName, err)
+			code = exitFailure
+			continue
+		}
+		errs, ok := checkstyle.ValidateConfig(conf).(checkstyle.ConfigErrors)
+		if !ok {
+			log.Printf("%v: config is valid\n", fileName)
+			continue
+		}
+		for _, e := range errs {
+			log.Printf("%v: %v: %v\n", fileName, e.Key, e.Err)
+		}
+		if code == 0 {
+			code = exitFatal
+		}
+	}
+	return code
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const paramsSrc = "package a\n\nfunc f(a, b, c, d int) {\n}\n"
+
+func writeConfigs(t *testing.T, root string, configs map[string]string) {
+	for name, conf := range configs {
+		fileName := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fileName, []byte(conf), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func paramsProblems(t *testing.T, style *styleConfig) int {
+	ps, err := style.checker.Check("a.go", []byte(paramsSrc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(ps)
+}
+
+func TestConfigFinder(t *testing.T) {
+	root, err := ioutil.TempDir("", "gocheckstyle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	writeConfigs(t, root, map[string]string{
+		"base.json":    `{"ignore": ["tmp/*"]}`,
+		".gostyle":     "root: true\nparams_num: 3\nignore:\n  - gen/*\n",
+		"a/.gostyle":   `{"params_num": 5, "ignore": ["b/*.pb.go"]}`,
+		"a/b/x.go":     paramsSrc,
+		"c/.gostyle":   `{"root": true, "generated": "check"}`,
+		"c/d/.gostyle": `{"params_num": 2}`,
+	})
+	finder, err := newConfigFinder(filepath.Join(root, "base.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the root config stops the search, the nearest config wins
+	style, err := finder.find(filepath.Join(root, "a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(style.layers) != 3 || paramsProblems(t, style) != 0 {
+		t.Fatal("expect base, root and a configs merged", len(style.layers))
+	}
+	style, _ = finder.find(root)
+	if len(style.layers) != 2 || paramsProblems(t, style) != 1 {
+		t.Fatal("expect params_num of root config", len(style.layers))
+	}
+	style, _ = finder.find(filepath.Join(root, "c", "d"))
+	if len(style.layers) != 3 || paramsProblems(t, style) != 1 {
+		t.Fatal("expect the search stopped at c", len(style.layers))
+	}
+	style, _ = finder.find(filepath.Join(root, "c"))
+	if len(style.layers) != 2 || paramsProblems(t, style) != 0 {
+		t.Fatal("expect params_num of the parent not merged above c", len(style.layers))
+	}
+
+	// the ignore patterns are relative to the config directories, and to
+	// the current directory for the base config
+	style, _ = finder.find(filepath.Join(root, "a", "b"))
+	if !style.isIgnore(filepath.Join(root, "a", "b", "x.pb.go")) || style.isIgnore(filepath.Join(root, "a", "b", "x.go")) {
+		t.Fatal("expect ignore relative to a")
+	}
+	if !style.isIgnore(filepath.Join(root, "gen", "x.go")) || !style.isIgnore("tmp/x.go") {
+		t.Fatal("expect ignore of root and base configs")
+	}
+
+	// the generated files are skipped unless the merged config checks them
+	generated := []byte("// Code generated by hand. DO NOT EDIT.\n\n" + paramsSrc)
+	if !style.skips(generated) || style.skips([]byte(paramsSrc)) {
+		t.Fatal("expect generated file skipped")
+	}
+	style, _ = finder.find(filepath.Join(root, "c", "d"))
+	if style.skips(generated) {
+		t.Fatal("expect generated file checked under c")
+	}
+
+	// the -config file is not discovered again
+	finder, err = newConfigFinder(filepath.Join(root, "a", ".gostyle"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	style, _ = finder.find(filepath.Join(root, "a", "b"))
+	if len(style.layers) != 2 || style.layers[1].dir != root {
+		t.Fatal("expect base config not discovered", len(style.layers))
+	}
+}
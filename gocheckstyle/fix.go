@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/qiniu/checkstyle"
+)
+
+// maxFixRounds limits the rounds of fixing, the edits overlapping others
+// are applied in the next round.
+const maxFixRounds = 10
+
+func fixFiles(checker checkstyle.Checker, files map[string][]byte) map[string][]byte {
+	fixed := map[string][]byte{}
+	for k, v := range files {
+		fixed[k] = v
+	}
+	for i := 0; i < maxFixRounds; i++ {
+		ps, err := checker.CheckPackage(fixed)
+		if err != nil {
+			break
+		}
+		edits := map[string][]checkstyle.TextEdit{}
+		for _, p := range ps {
+			edits[p.Position.Filename] = append(edits[p.Position.Filename], p.Fix...)
+		}
+		changed := false
+		for fileName, fileEdits := range edits {
+			src := checkstyle.ApplyEdits(fixed[fileName], fileEdits)
+			if !bytes.Equal(src, fixed[fileName]) {
+				fixed[fileName] = src
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return fixed
+}
+
+func writeFixes(files, fixed map[string][]byte) {
+	fileNames := make([]string, 0, len(files))
+	for k := range files {
+		fileNames = append(fileNames, k)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		if bytes.Equal(files[fileName], fixed[fileName]) {
+			continue
+		}
+		if *diffOption {
+			writeDiff(os.Stdout, fileName, files[fileName], fixed[fileName])
+			continue
+		}
+		fi, err := os.Stat(fileName)
+		if err == nil {
+			err = ioutil.WriteFile(fileName, fixed[fileName], fi.Mode())
+		}
+		if err != nil {
+			addFailure("Write File Fail %v %v", fileName, err)
+			fixed[fileName] = files[fileName]
+		}
+	}
+}
+
+func splitLines(src []byte) []string {
+	lines := strings.SplitAfter(string(src), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffOp is a line of the diff, kind is one of ' ', '-' and '+'.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines computes the line diff by the longest common subsequence of
+// the lines between the common prefix and suffix.
+func diffLines(a, b []string) []diffOp {
+	prefix, suffix := 0, 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	var ops []diffOp
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, lcsDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func lcsDiff(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if i < len(a) && j < len(b) && a[i] == b[j] {
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		} else if j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]) {
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		} else {
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	return ops
+}
+
+const diffContext = 3
+
+// writeDiff writes the unified diff of a file before and after fixing.
+func writeDiff(w io.Writer, fileName string, before, after []byte) {
+	ops := diffLines(splitLines(before), splitLines(after))
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", fileName, fileName)
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		from := start - diffContext
+		if from < 0 {
+			from = 0
+		}
+		// extend the hunk until diffContext*2 unchanged lines
+		end, same := start, 0
+		for ; end < len(ops) && same <= diffContext*2; end++ {
+			if ops[end].kind == ' ' {
+				same++
+			} else {
+				same = 0
+			}
+		}
+		end -= same - diffContext
+		if end > len(ops) {
+			end = len(ops)
+		}
+		writeHunk(w, ops, from, end)
+		start = end
+	}
+}
+
+func writeHunk(w io.Writer, ops []diffOp, from, end int) {
+	aLine, bLine := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			aLine++
+		}
+		if op.kind != '-' {
+			bLine++
+		}
+	}
+	aCount, bCount := 0, 0
+	for _, op := range ops[from:end] {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+	fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+	for _, op := range ops[from:end] {
+		line := op.line
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n\\ No newline at end of file\n"
+		}
+		fmt.Fprintf(w, "%c%s", op.kind, line)
+	}
+}
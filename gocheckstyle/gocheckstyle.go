@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
@@ -13,52 +15,51 @@ import (
 	"github.com/qiniu/checkstyle"
 )
 
-const defaultConfig = `{
-    "file_line":200,
-    "_file_line_comment": "file line count limit",
-    "func_line":50,
-    "_func_line_comment": "function line count limit",
-    "params_num":4,
-    "_params_num_comment": "function parameter count limit",
-    "results_num":3,
-    "_results_num_comment": "function return variable count limit",
-    "formated": true,
-    "_formated_comment": "gofmt",
-    "pkg_name": true,
-    "_pkg_name_comment": "package name should not contain _ and camel",
-    "camel_name": true,
-    "_camel_name_comment": "const/var/function/import name should use camel name",
-    "ignore":[
-        "tmp/*",
-        "src/tmp.go"
-    ],
-    "_ignore_comment":"ignore file",
-    "fatal":[
-        "formated"
-    ],
-    "_fatal_comment": "put the check rule of error level here"
-}`
-
-var config = flag.String("config", "", "config json file")
-var reporterOption = flag.String("reporter", "plain", "report output format, plain or xml")
-
-var checker checkstyle.Checker
-var reporter Reporter
+// defaultConfig is used if there is no -config.
+const defaultConfig = "extends: default"
 
-type Ignore struct {
-	Files []string `json:"ignore"`
-}
+var config = flag.String("config", "", "base config file in json, yaml or toml, the .gostyle files found from the checked directories up are merged onto it")
+var reporterOption = flag.String("reporter", "plain", "report output format, plain, xml or json")
+var fixOption = flag.Bool("fix", false, "apply the suggested fixes to the files")
+var diffOption = flag.Bool("diff", false, "print the suggested fixes as diff instead of applying them")
+var failLevel = flag.String("fail-level", "error", "exit with 1 if there are problems of this severity or higher, info, warning or error")
 
-var ignore Ignore
+var reporter Reporter
 
 type Reporter interface {
 	ReceiveProblems(checker checkstyle.Checker, file string, problems []checkstyle.Problem)
 	Report()
 }
 
+// failures are the files which could not be checked, they are reported
+// after the problems.
+var failures []string
+
+// skipped is the count of the files skipped by the checkers, which are the
+// generated files.
+var skipped int
+
+// failed is true if there are problems of failSeverity or higher.
+var failed bool
+var failSeverity checkstyle.Severity
+
+const (
+	exitFatal   = 1
+	exitFailure = 2
+)
+
+func addFailure(format string, args ...interface{}) {
+	failures = append(failures, fmt.Sprintf(format, args...))
+}
+
+var severities = []checkstyle.Severity{
+	checkstyle.SeverityInfo,
+	checkstyle.SeverityWarning,
+	checkstyle.SeverityError,
+}
+
 type plainReporter struct {
-	normalProblems []*checkstyle.Problem
-	fatalProblems  []*checkstyle.Problem
+	problems map[checkstyle.Severity][]*checkstyle.Problem
 }
 
 func (_ *plainReporter) printProblems(ps []*checkstyle.Problem) {
@@ -68,61 +69,56 @@ func (_ *plainReporter) printProblems(ps []*checkstyle.Problem) {
 }
 
 func (p *plainReporter) Report() {
-	if len(p.normalProblems) != 0 {
-		log.Printf(" ========= There are %d normal problems ========= \n", len(p.normalProblems))
-		p.printProblems(p.normalProblems)
-	}
-
-	if len(p.fatalProblems) != 0 {
-		log.Printf(" ========= There are %d fatal problems ========= \n", len(p.fatalProblems))
-		p.printProblems(p.fatalProblems)
-		os.Exit(1)
+	total := 0
+	for _, severity := range severities {
+		ps := p.problems[severity]
+		if len(ps) != 0 {
+			log.Printf(" ========= There are %d %v problems ========= \n", len(ps), severity)
+			p.printProblems(ps)
+		}
+		total += len(ps)
 	}
-	if len(p.normalProblems) == 0 && len(p.fatalProblems) == 0 {
+	if total == 0 {
 		log.Println(" ========= There are no problems ========= ")
 	}
+	if skipped != 0 {
+		log.Printf(" ========= Skipped %d generated files ========= \n", skipped)
+	}
 }
 
 func (p *plainReporter) ReceiveProblems(checker checkstyle.Checker, file string, problems []checkstyle.Problem) {
 	for i, problem := range problems {
-		if checker.IsFatal(&problem) {
-			p.fatalProblems = append(p.fatalProblems, &problems[i])
-		} else {
-			p.normalProblems = append(p.normalProblems, &problems[i])
-		}
+		p.problems[problem.Severity] = append(p.problems[problem.Severity], &problems[i])
 	}
 }
 
 type xmlReporter struct {
 	problems map[string][]checkstyle.Problem
-	hasFatal bool
 }
 
 func (x *xmlReporter) printProblems(ps []checkstyle.Problem) {
 	format := "\t\t<error line=\"%d\" column=\"%d\" severity=\"%s\" message=\"%s\" source=\"checkstyle.%s\" />\n"
 	for _, p := range ps {
-		severity := "warning"
-		if checker.IsFatal(&p) {
-			severity = "error"
-			x.hasFatal = true
-		}
-		log.Printf(format, p.Position.Line, p.Position.Column, severity, p.Description, p.Type)
+		log.Printf(format, p.Position.Line, p.Position.Column, p.Severity, xmlEscape(p.Description), p.Type)
 	}
 }
 
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
 func (x *xmlReporter) Report() {
 	log.SetFlags(0)
 	log.Print(xml.Header)
 	log.Println(`<checkstyle version="4.3">`)
 	for k, v := range x.problems {
-		log.Printf("\t<file name=\"%s\">\n", k)
+		log.Printf("\t<file name=\"%s\">\n", xmlEscape(k))
 		x.printProblems(v)
 		log.Println("\t</file>")
 	}
 	log.Println("</checkstyle>")
-	if x.hasFatal {
-		os.Exit(1)
-	}
 }
 
 func (x *xmlReporter) ReceiveProblems(checker checkstyle.Checker, file string, problems []checkstyle.Problem) {
@@ -132,34 +128,52 @@ func (x *xmlReporter) ReceiveProblems(checker checkstyle.Checker, file string, p
 	x.problems[file] = problems
 }
 
+// jsonProblem is a problem in the json report, data is the values measured
+// by the rule.
+type jsonProblem struct {
+	File     string                 `json:"file"`
+	Line     int                    `json:"line"`
+	Column   int                    `json:"column"`
+	Severity checkstyle.Severity    `json:"severity"`
+	Type     checkstyle.ProblemType `json:"type"`
+	Message  string                 `json:"message"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+type jsonReporter struct {
+	problems []jsonProblem
+}
+
+func (j *jsonReporter) Report() {
+	out, _ := json.MarshalIndent(j.problems, "", "\t")
+	os.Stdout.Write(append(out, '\n'))
+}
+
+func (j *jsonReporter) ReceiveProblems(checker checkstyle.Checker, file string, problems []checkstyle.Problem) {
+	for _, p := range problems {
+		j.problems = append(j.problems, jsonProblem{
+			File: file, Line: p.Position.Line, Column: p.Position.Column,
+			Severity: p.Severity, Type: p.Type, Message: p.Description, Data: p.Data,
+		})
+	}
+}
+
 func main() {
+	flag.Usage = usage
 	flag.Parse()
 
 	files := flag.Args()
-
-	if reporterOption == nil || *reporterOption != "xml" {
-		reporter = &plainReporter{}
-	} else {
-		reporter = &xmlReporter{problems: map[string][]checkstyle.Problem{}}
+	if len(files) != 0 && files[0] == "validate-config" {
+		os.Exit(validateConfigs(files[1:]))
 	}
+
 	var err error
-	var conf []byte
-	if *config == "" {
-		conf = []byte(defaultConfig)
-	} else {
-		conf, err = ioutil.ReadFile(*config)
-		if err != nil {
-			log.Fatalf("Open config %v fail %v\n", *config, err)
-		}
-	}
-	err = json.Unmarshal(conf, &ignore)
-	if err != nil {
-		log.Fatalf("Parse config %v fail \n", *config, err)
-	}
-	checker, err = checkstyle.New(conf)
-	if err != nil {
-		log.Fatalf("New checker fail %v\n", err)
+	failSeverity, err = checkstyle.ParseSeverity(*failLevel)
+	if err != nil || failSeverity == checkstyle.SeverityOff {
+		log.Fatalf("Invalid fail level %v\n", *failLevel)
 	}
+	reporter = newReporter()
+	loadConfig()
 
 	if len(files) == 0 {
 		files = []string{"."}
@@ -172,6 +186,41 @@ func main() {
 		}
 	}
 	reporter.Report()
+	if len(failures) != 0 {
+		reportFailures()
+		os.Exit(exitFailure)
+	}
+	if failed {
+		os.Exit(exitFatal)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: gocheckstyle [flags] [files or dirs]\n")
+	fmt.Fprintf(os.Stderr, "       gocheckstyle [-config file] validate-config [config files]\n")
+	flag.PrintDefaults()
+}
+
+func newReporter() Reporter {
+	switch *reporterOption {
+	case "xml":
+		return &xmlReporter{problems: map[string][]checkstyle.Problem{}}
+	case "json":
+		return &jsonReporter{problems: []jsonProblem{}}
+	}
+	return &plainReporter{problems: map[checkstyle.Severity][]*checkstyle.Problem{}}
+}
+
+func reportFailures() {
+	out := log.New(os.Stderr, "", log.LstdFlags)
+	if _, ok := reporter.(*xmlReporter); ok {
+		// the xml report is written to stderr
+		out = log.New(os.Stdout, "", 0)
+	}
+	out.Printf(" ========= There are %d files failed to check ========= \n", len(failures))
+	for _, v := range failures {
+		out.Println(v)
+	}
 }
 
 func isDir(filename string) bool {
@@ -180,48 +229,89 @@ func isDir(filename string) bool {
 }
 
 func checkFile(fileName string) {
-	file, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		log.Fatalf("Read File Fail %v %v\n", fileName, err)
+	checkPackage([]string{fileName})
+}
+
+func checkPackage(fileNames []string) {
+	style := findConfig(filepath.Dir(fileNames[0]))
+	checker := style.checker
+	files := map[string][]byte{}
+	for _, fileName := range fileNames {
+		file, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			addFailure("Read File Fail %v %v", fileName, err)
+			continue
+		}
+		files[fileName] = file
+		if style.skips(file) {
+			skipped++
+		}
+	}
+	if len(files) == 0 {
+		return
 	}
 
-	ps, err := checker.Check(fileName, file)
-	if err != nil {
-		log.Fatalf("Parse File Fail %v %v\n", fileName, err)
+	if *fixOption || *diffOption {
+		fixed := fixFiles(checker, files)
+		writeFixes(files, fixed)
+		if !*diffOption {
+			files = fixed
+		}
 	}
 
-	reporter.ReceiveProblems(checker, fileName, ps)
-}
+	ps, err := checker.CheckPackage(files)
+	if err != nil {
+		addFailure("Check Package Fail %v %v", filepath.Dir(fileNames[0]), err)
+		return
+	}
 
-func isIgnoreFile(fileName string) bool {
-	for _, v := range ignore.Files {
-		if ok, _ := filepath.Match(v, fileName); ok {
-			return true
+	problems := map[string][]checkstyle.Problem{}
+	for _, p := range ps {
+		if p.Type == checkstyle.ParseError {
+			addFailure("Parse File Fail %v: %s", p.Position, p.Description)
 		}
+		if p.Severity >= failSeverity {
+			failed = true
+		}
+		problems[p.Position.Filename] = append(problems[p.Position.Filename], p)
+	}
+	for _, fileName := range fileNames {
+		reporter.ReceiveProblems(checker, fileName, problems[fileName])
 	}
-	return false
 }
 
-func isIgnoreDir(dir string) bool {
-	for _, v := range ignore.Files {
-		if ok, _ := filepath.Match(v, dir); ok {
-			return true
-		}
+func isIgnore(path string, isDir bool) bool {
+	dir := path
+	if !isDir {
+		dir = filepath.Dir(path)
 	}
-	return false
+	return findConfig(dir).isIgnore(path)
 }
 
 func checkDir(dir string) {
-	if isIgnoreDir(dir) {
+	if isIgnore(dir, true) {
 		return
 	}
+	var dirs []string
+	packages := map[string][]string{}
 	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err == nil && info.IsDir() && isIgnoreDir(path) {
+		if err != nil {
+			addFailure("Walk Fail %v %v", path, err)
+			return nil
+		}
+		if info.IsDir() && isIgnore(path, true) {
 			return filepath.SkipDir
 		}
-		if err == nil && !info.IsDir() && strings.HasSuffix(path, ".go") && !isIgnoreFile(path) {
-			checkFile(path)
+		if !info.IsDir() && strings.HasSuffix(path, ".go") && !isIgnore(path, false) {
+			pkgDir := filepath.Dir(path)
+			if _, ok := packages[pkgDir]; !ok {
+				dirs = append(dirs, pkgDir)
+			}
+			packages[pkgDir] = append(packages[pkgDir], path)
 		}
-		return err
+		return nil
 	})
+	for _, pkgDir := range dirs {
+		checkPackage(packages[pkgDir])
+	}
 }
@@ -0,0 +1,314 @@
+package checkstyle
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importGroupRule checks the import blocks are grouped into the standard
+// library, the third-party and the local imports, and sorted in the groups.
+// The config is the prefix of the local imports, or true to use the module
+// path in go.mod.
+type importGroupRule struct {
+	local   string
+	modules map[string]string
+}
+
+func (*importGroupRule) Name() string      { return string(ImportGroup) }
+func (*importGroupRule) Type() ProblemType { return ImportGroup }
+
+func (r *importGroupRule) Decode(config json.RawMessage) (bool, error) {
+	r.modules = map[string]string{}
+	var enabled bool
+	if err := json.Unmarshal(config, &enabled); err == nil {
+		return enabled, nil
+	}
+	if err := json.Unmarshal(config, &r.local); err != nil {
+		return false, errors.New("expect bool or local import prefix")
+	}
+	return r.local != "", nil
+}
+
+func (r *importGroupRule) CheckNode(f *File, node ast.Node) {
+	decl, ok := node.(*ast.GenDecl)
+	if !ok || decl.Tok != token.IMPORT || !decl.Lparen.IsValid() || len(decl.Specs) == 0 {
+		return
+	}
+	local := r.local
+	if local == "" {
+		local = r.modulePath(filepath.Dir(f.FileName))
+	}
+	var actual [][]*ast.ImportSpec
+	expect := make([][]*ast.ImportSpec, 3)
+	lastLine := 0
+	for _, spec := range decl.Specs {
+		importSpec := spec.(*ast.ImportSpec)
+		start := importSpec.Pos()
+		if importSpec.Doc != nil {
+			start = importSpec.Doc.Pos()
+		}
+		if line := f.Fset.Position(start).Line; len(actual) == 0 || line > lastLine+1 {
+			actual = append(actual, nil)
+		}
+		lastLine = f.Fset.Position(importSpec.End()).Line
+		actual[len(actual)-1] = append(actual[len(actual)-1], importSpec)
+		group := importGroup(importPath(importSpec), local)
+		expect[group] = append(expect[group], importSpec)
+	}
+	var groups [][]*ast.ImportSpec
+	for _, group := range expect {
+		if len(group) != 0 {
+			sort.SliceStable(group, func(i, j int) bool { return importPath(group[i]) < importPath(group[j]) })
+			groups = append(groups, group)
+		}
+	}
+	if sameGroups(actual, groups) {
+		return
+	}
+	desc := "imports should be grouped by std, third-party and local, and sorted:"
+	for _, group := range groups {
+		var paths []string
+		for _, spec := range group {
+			paths = append(paths, importPath(spec))
+		}
+		desc += " (" + strings.Join(paths, " ") + ")"
+	}
+	f.ReportFix(decl.Pos(), ImportGroup, desc, importFix(f, decl, groups))
+}
+
+// importGroup returns 0 for the standard library, 1 for the third-party
+// and 2 for the local imports.
+func importGroup(path, local string) int {
+	if local != "" && (path == local || strings.HasPrefix(path, strings.TrimSuffix(local, "/")+"/")) {
+		return 2
+	}
+	if !strings.Contains(strings.Split(path, "/")[0], ".") {
+		return 0
+	}
+	return 1
+}
+
+func importPath(spec *ast.ImportSpec) string {
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return path
+}
+
+func sameGroups(a, b [][]*ast.ImportSpec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// importFix rewrites the import block in the groups, it returns nil if
+// there are comments in the block.
+func importFix(f *File, decl *ast.GenDecl, groups [][]*ast.ImportSpec) []TextEdit {
+	for _, c := range f.AST.Comments {
+		if c.Pos() > decl.Lparen && c.End() < decl.Rparen {
+			return nil
+		}
+	}
+	text := "\n"
+	for i, group := range groups {
+		if i != 0 {
+			text += "\n"
+		}
+		for _, spec := range group {
+			start := f.Fset.Position(spec.Pos()).Offset
+			end := f.Fset.Position(spec.End()).Offset
+			text += "\t" + string(f.Src[start:end]) + "\n"
+		}
+	}
+	start := f.Fset.Position(decl.Lparen).Offset + 1
+	end := f.Fset.Position(decl.Rparen).Offset
+	return []TextEdit{{Offset: start, End: end, NewText: []byte(text)}}
+}
+
+// modulePath returns the module path in the go.mod of dir or its parents.
+func (r *importGroupRule) modulePath(dir string) string {
+	dir, _ = filepath.Abs(dir)
+	if path, ok := r.modules[dir]; ok {
+		return path
+	}
+	path := readModulePath(filepath.Join(dir, "go.mod"))
+	if parent := filepath.Dir(dir); path == "" && parent != dir {
+		path = r.modulePath(parent)
+	}
+	r.modules[dir] = path
+	return path
+}
+
+func readModulePath(fileName string) string {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// importPattern matches the import paths in the files, the patterns are
+// globs, or end with /... to match the subdirectories. The file patterns
+// with / are relative to the directory of the config declaring them, the
+// others match the file names. The files are all files if omitted.
+type importPattern struct {
+	Path  string   `json:"path"`
+	Files []string `json:"files"`
+}
+
+func (p *importPattern) match(importPath, fileName string) bool {
+	if !matchPath(p.Path, importPath) {
+		return false
+	}
+	fileName, _ = filepath.Abs(fileName)
+	fileName = filepath.ToSlash(fileName)
+	for _, v := range p.Files {
+		if !strings.Contains(v, "/") && matchPath(v, path.Base(fileName)) || matchPath(v, fileName) {
+			return true
+		}
+	}
+	return len(p.Files) == 0
+}
+
+// resolveImportFiles makes the file patterns with / in the imports config
+// absolute, dir is the directory of the config.
+func resolveImportFiles(config map[string]interface{}, dir string) {
+	imports, ok := config["imports"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	dir, _ = filepath.Abs(dir)
+	for _, key := range []string{"deny", "dot", "blank"} {
+		patterns, _ := imports[key].([]interface{})
+		for _, v := range patterns {
+			pattern, _ := v.(map[string]interface{})
+			files, _ := pattern["files"].([]interface{})
+			for i, file := range files {
+				if name, ok := file.(string); ok && strings.Contains(name, "/") && !path.IsAbs(name) {
+					files[i] = path.Join(filepath.ToSlash(dir), name)
+				}
+			}
+		}
+	}
+}
+
+func (p *importPattern) validate() error {
+	for _, v := range append([]string{p.Path}, p.Files...) {
+		if _, err := path.Match(strings.TrimSuffix(v, "/..."), ""); err != nil || v == "" {
+			return errors.New("bad pattern \"" + v + "\"")
+		}
+	}
+	return nil
+}
+
+func matchPath(pattern, name string) bool {
+	if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+		return name == prefix || strings.HasPrefix(name, prefix+"/")
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// deniedImport is an import not allowed, with the message and severity of
+// the problem. Severity is nil if the severity of the rule is used.
+type deniedImport struct {
+	importPattern
+	Message  string    `json:"message"`
+	Severity *Severity `json:"severity"`
+}
+
+// importsRule checks the imports by the deny list, and restricts the dot
+// and blank imports to the paths in the allow lists if they are given.
+type importsRule struct {
+	Deny  []*deniedImport  `json:"deny"`
+	Dot   []*importPattern `json:"dot"`
+	Blank []*importPattern `json:"blank"`
+}
+
+func (*importsRule) Name() string      { return string(Imports) }
+func (*importsRule) Type() ProblemType { return Imports }
+func (*importsRule) CheckTests() bool  { return true }
+
+func (r *importsRule) Decode(config json.RawMessage) (bool, error) {
+	if err := decodeStrict(config, r); err != nil {
+		return false, err
+	}
+	patterns := append(r.Dot, r.Blank...)
+	for _, v := range r.Deny {
+		if v.Severity != nil && *v.Severity == SeverityOff {
+			return false, errors.New("severity of denied import \"" + v.Path + "\" could not be off")
+		}
+		patterns = append(patterns, &v.importPattern)
+	}
+	for _, v := range patterns {
+		if err := v.validate(); err != nil {
+			return false, err
+		}
+	}
+	return r.Deny != nil || r.Dot != nil || r.Blank != nil, nil
+}
+
+func (r *importsRule) CheckNode(f *File, node ast.Node) {
+	spec, ok := node.(*ast.ImportSpec)
+	if !ok {
+		return
+	}
+	imported := importPath(spec)
+	for _, v := range r.Deny {
+		if v.match(imported, f.FileName) {
+			desc := "import " + spec.Path.Value + " is denied"
+			if v.Message != "" {
+				desc += ": " + v.Message
+			}
+			severity := SeverityOff
+			if v.Severity != nil {
+				severity = *v.Severity
+			}
+			f.ReportSeverity(spec.Pos(), Imports, desc, severity)
+			return
+		}
+	}
+	if spec.Name == nil {
+		return
+	}
+	if spec.Name.Name == "." && r.Dot != nil && !matchAny(r.Dot, imported, f.FileName) {
+		f.Report(spec.Pos(), Imports, "dot import of "+spec.Path.Value+" is not allowed here")
+	} else if spec.Name.Name == "_" && r.Blank != nil && !matchAny(r.Blank, imported, f.FileName) {
+		f.Report(spec.Pos(), Imports, "blank import of "+spec.Path.Value+" is not allowed here")
+	}
+}
+
+func matchAny(patterns []*importPattern, importPath, fileName string) bool {
+	for _, v := range patterns {
+		if v.match(importPath, fileName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestImportGroup(t *testing.T) {
+	fileName := "testdata/imports/imports.go"
+	file := readFile("imports/imports.go")
+	_checker := newChecker(`{"import_group": true}`)
+	ps, _ := _checker.Check(fileName, file)
+	if len(ps) != 2 {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	expect := "imports should be grouped by std, third-party and local, and sorted: (fmt os) (github.com/pkg/errors) (example.com/local/util example.com/local/x)"
+	if ps[0].Position.Line != 3 || ps[0].Description != expect || len(ps[0].Fix) != 1 {
+		t.Fatal("unexpected problem", ps[0].Position, ps[0].Description)
+	}
+	if ps[1].Position.Line != 12 || len(ps[1].Fix) != 0 {
+		t.Fatal("expect block with comments not fixed", ps[1])
+	}
+	ps, _ = _checker.Check(fileName, fixAll(file, ps))
+	if len(ps) != 1 || ps[0].Position.Line != 13 {
+		t.Fatal("expect 1 error after fix but ", ps)
+	}
+
+	ps, _ = newChecker(`{"import_group": "github.com/pkg"}`).Check(fileName, file)
+	if len(ps) != 3 || ps[2].Position.Line != 20 {
+		t.Fatal("expect local prefix configured", ps)
+	}
+}
+
+func TestImports(t *testing.T) {
+	config := `
+imports:
+  deny:
+    - path: io/ioutil
+      message: use io and os instead
+      severity: error
+    - path: example.com/local/legacy/...
+      files: ["cmd/..."]
+    - path: github.com/pkg/*
+      files: ["*_test.go"]
+  dot:
+    - path: github.com/onsi/ginkgo
+  blank:
+    - path: github.com/go-sql-driver/mysql
+      files: [main.go]
+`
+	_checker := newChecker(config)
+	file := readFile("imports/deny.go")
+	ps, _ := _checker.Check("cmd/app/deny.go", file)
+	expect := []string{
+		`blank import of "github.com/go-sql-driver/mysql" is not allowed here`,
+		`import "io/ioutil" is denied: use io and os instead`,
+		`dot import of "strings" is not allowed here`,
+		`import "example.com/local/legacy/store" is denied`,
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 4 error but ", ps)
+	}
+	for i, p := range ps {
+		if p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Description)
+		}
+	}
+	if ps[0].Severity != SeverityWarning || ps[1].Severity != SeverityError || ps[1].Position.Line != 6 {
+		t.Fatal("unexpected severity", ps[0].Severity, ps[1].Severity)
+	}
+
+	ps, _ = _checker.Check("cmd/app/main.go", file)
+	if len(ps) != 3 {
+		t.Fatal("expect blank import allowed in main.go but ", ps)
+	}
+	ps, _ = _checker.Check("deny_test.go", file)
+	if len(ps) != 4 || ps[1].Description != `import "github.com/pkg/errors" is denied` {
+		t.Fatal("expect patterns scoped by file but ", ps)
+	}
+
+	if _, err := New([]byte(`{"imports": {"deny": [{"path": "a/[", "message": "bad"}]}}`)); err == nil {
+		t.Fatal("expect bad pattern error")
+	}
+	config2 := []byte(`{"imports": {"deny": [{"path": "io/ioutil", "mesage": "x"}]}}`)
+	if _, err := New(config2); err == nil || ValidateConfig(config2) == nil {
+		t.Fatal("expect unknown key of imports")
+	}
+	config3 := []byte(`{"imports": {"deny": [{"path": "io/ioutil", "severity": "off"}]}}`)
+	if _, err := New(config3); err == nil || ValidateConfig(config3) == nil {
+		t.Fatal("expect severity off of denied import rejected")
+	}
+}
+
+func TestImportsFiles(t *testing.T) {
+	config, err := ResolveConfig([]byte(`{"imports": {"deny": [{"path": "io/ioutil", "files": ["cmd/..."]}]}}`), "testdata/imports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_checker := newChecker(string(config))
+	file := readFile("imports/deny.go")
+	if ps, _ := _checker.Check("testdata/imports/cmd/deny.go", file); len(ps) != 1 {
+		t.Fatal("expect files relative to the config directory but ", ps)
+	}
+	if ps, _ := _checker.Check("cmd/deny.go", file); len(ps) != 0 {
+		t.Fatal("expect files outside the config directory not matched but ", ps)
+	}
+}
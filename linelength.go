@@ -0,0 +1,127 @@
+package checkstyle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// lineExemptions are the kinds of the lines which could exceed the limit.
+var lineExemptions = []string{"import", "string", "url", "go_generate"}
+
+// lineLengthRule reports the lines wider than the limit. The config is the
+// limit, or an object like {"limit": 120, "tab_width": 4, "exempt": ["url"]}.
+type lineLengthRule struct {
+	Limit    int      `json:"limit"`
+	TabWidth int      `json:"tab_width"`
+	Exempt   []string `json:"exempt"`
+}
+
+func (*lineLengthRule) Name() string      { return string(LineLength) }
+func (*lineLengthRule) Type() ProblemType { return LineLength }
+
+func (r *lineLengthRule) Decode(config json.RawMessage) (bool, error) {
+	r.TabWidth = 4
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(config), []byte("{")) {
+		err = decodeStrict(config, r)
+	} else {
+		err = json.Unmarshal(config, &r.Limit)
+	}
+	if err != nil {
+		return false, err
+	}
+	if r.Exempt == nil {
+		r.Exempt = lineExemptions
+	}
+	for _, v := range r.Exempt {
+		if !contains(lineExemptions, v) {
+			return false, errors.New("unknown exemption " + v + ", expect " + strings.Join(lineExemptions, ", "))
+		}
+	}
+	if r.Limit < 0 || r.TabWidth <= 0 {
+		return false, errors.New("limit and tab_width should be positive")
+	}
+	return r.Limit > 0, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *lineLengthRule) CheckFile(f *File) {
+	exempt := r.exemptLines(f)
+	tokFile := f.Fset.File(f.AST.Pos())
+	for i, line := range bytes.Split(f.Src, []byte("\n")) {
+		width := r.width(bytes.TrimRight(line, "\r"))
+		if width > r.Limit && !exempt[i+1] {
+			desc := "line length " + strconv.Itoa(width) + " more than " + strconv.Itoa(r.Limit)
+			f.Report(tokFile.LineStart(i+1), LineLength, desc)
+		}
+	}
+}
+
+// width returns the width of line, the tabs expand to the next multiple of
+// the tab width.
+func (r *lineLengthRule) width(line []byte) int {
+	width := 0
+	for len(line) != 0 {
+		c, size := utf8.DecodeRune(line)
+		if c == '\t' {
+			width += r.TabWidth - width%r.TabWidth
+		} else {
+			width++
+		}
+		line = line[size:]
+	}
+	return width
+}
+
+// exemptLines returns the lines exempted by the config. The string literals
+// are exempted if they end beyond the limit. The lines are not adjusted by
+// the //line directives, they are compared with the lines of f.Src.
+func (r *lineLengthRule) exemptLines(f *File) map[int]bool {
+	lines := map[int]bool{}
+	line := func(pos token.Pos) int {
+		return f.Fset.PositionFor(pos, false).Line
+	}
+	addLines := func(node ast.Node) {
+		for i := line(node.Pos()); i <= line(node.End()); i++ {
+			lines[i] = true
+		}
+	}
+	ast.Inspect(f.AST, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.GenDecl:
+			if n.Tok == token.IMPORT && contains(r.Exempt, "import") {
+				addLines(n)
+			}
+		case *ast.BasicLit:
+			end := f.Fset.PositionFor(n.End(), false)
+			lineEnd := f.Src[end.Offset-end.Column+1 : end.Offset]
+			if n.Kind == token.STRING && contains(r.Exempt, "string") && r.width(lineEnd) > r.Limit {
+				addLines(n)
+			}
+		}
+		return true
+	})
+	for _, group := range f.AST.Comments {
+		for _, c := range group.List {
+			url := strings.Contains(c.Text, "://") && contains(r.Exempt, "url")
+			if url || strings.HasPrefix(c.Text, "//go:generate") && contains(r.Exempt, "go_generate") {
+				addLines(c)
+			}
+		}
+	}
+	return lines
+}
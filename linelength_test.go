@@ -0,0 +1,36 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestLineLength(t *testing.T) {
+	fileName := "line_length.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"line_length": 80}`).Check(fileName, file)
+	if len(ps) != 2 {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	if ps[0].Position.Line != 13 || ps[0].Description != "line length 83 more than 80" || ps[1].Position.Line != 14 {
+		t.Fatal("unexpected problem", ps[0].Position, ps[0].Description)
+	}
+
+	ps, _ = newChecker(`{"line_length": {"limit": 80, "tab_width": 8, "exempt": ["import"]}}`).Check(fileName, file)
+	if len(ps) != 6 || ps[0].Position.Line != 3 || ps[3].Description != "line length 87 more than 80" {
+		t.Fatal("expect 6 error but ", ps)
+	}
+	if ps[5].Position.Filename != fileName || ps[5].Position.Line != 18 || ps[5].Description != "line length 88 more than 80" {
+		t.Fatal("unexpected problem after //line", ps[5].Position, ps[5].Description)
+	}
+
+	if _, err := New([]byte(`{"line_length": {"limit": 80, "exempt": ["urls"]}}`)); err == nil {
+		t.Fatal("expect unknown exemption")
+	}
+	config := []byte(`{"line_length": {"limt": 80}}`)
+	if _, err := New(config); err == nil {
+		t.Fatal("expect unknown key of line_length")
+	}
+	if err := ValidateConfig(config); err == nil {
+		t.Fatal("expect invalid config")
+	}
+}
@@ -0,0 +1,383 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// nameVisitor is called with each declared name of a file, kind describes
+// the declaration and local is true for the names which should start with
+// a small letter.
+type nameVisitor func(id *ast.Ident, kind string, local bool)
+
+func (visit nameVisitor) walkFile(f *ast.File) {
+	for _, v := range f.Decls {
+		switch decl := v.(type) {
+		case *ast.FuncDecl:
+			visit.walkFuncDecl(decl)
+			if decl.Body == nil {
+				break
+			}
+			ast.Inspect(decl.Body, func(node ast.Node) bool {
+				switch decl2 := node.(type) {
+				case *ast.GenDecl:
+					visit.walkGenDecl(decl2, false)
+				case *ast.FuncDecl:
+					visit.walkFuncDecl(decl2)
+				case *ast.AssignStmt:
+					visit.walkAssign(decl2)
+				case *ast.StructType:
+					visit.walkStruct(decl2)
+				}
+				return true
+			})
+		case *ast.GenDecl:
+			visit.walkGenDecl(decl, true)
+		}
+	}
+}
+
+func (visit nameVisitor) walkFuncType(fType *ast.FuncType) {
+	if fType.Params != nil {
+		for _, v := range fType.Params.List {
+			for _, pName := range v.Names {
+				visit(pName, "param", true)
+			}
+		}
+	}
+	if fType.Results != nil {
+		for _, v := range fType.Results.List {
+			for _, rName := range v.Names {
+				visit(rName, "return param", true)
+			}
+		}
+	}
+}
+
+func (visit nameVisitor) walkFuncDecl(funcDecl *ast.FuncDecl) {
+	visit(funcDecl.Name, "func", false)
+	visit.walkFuncType(funcDecl.Type)
+	receiver := funcDecl.Recv
+	if receiver != nil && len(receiver.List) != 0 && len(receiver.List[0].Names) != 0 {
+		visit(receiver.List[0].Names[0], "receiver", true)
+	}
+}
+
+func (visit nameVisitor) walkStruct(st *ast.StructType) {
+	if st.Fields == nil {
+		return
+	}
+	for _, v := range st.Fields.List {
+		for _, v2 := range v.Names {
+			visit(v2, "struct field", false)
+		}
+	}
+}
+
+func (visit nameVisitor) walkInterface(it *ast.InterfaceType) {
+	if it.Methods == nil {
+		return
+	}
+	for _, v := range it.Methods.List {
+		for _, v2 := range v.Names {
+			visit(v2, "interface method", false)
+		}
+		if v3, ok := v.Type.(*ast.FuncType); ok {
+			visit.walkFuncType(v3)
+		}
+	}
+}
+
+func (visit nameVisitor) walkValueName(decl *ast.GenDecl, kind string, top bool) {
+	for _, spec := range decl.Specs {
+		if vSpec, ok := spec.(*ast.ValueSpec); ok {
+			for _, name := range vSpec.Names {
+				visit(name, kind, !top)
+			}
+		} else if tSpec, ok := spec.(*ast.TypeSpec); ok {
+			visit(tSpec.Name, kind, false)
+			ast.Inspect(tSpec.Type, func(node ast.Node) bool {
+				switch decl2 := node.(type) {
+				case *ast.GenDecl:
+					visit.walkGenDecl(decl2, false)
+				case *ast.FuncDecl:
+					visit.walkFuncDecl(decl2)
+				case *ast.StructType:
+					visit.walkStruct(decl2)
+				case *ast.InterfaceType:
+					visit.walkInterface(decl2)
+				}
+				return true
+			})
+		} else if iSpec, ok := spec.(*ast.ImportSpec); ok && iSpec.Name != nil {
+			visit(iSpec.Name, "import", true)
+		}
+	}
+}
+
+func (visit nameVisitor) walkGenDecl(decl *ast.GenDecl, top bool) {
+	if decl.Tok == token.CONST {
+		visit.walkValueName(decl, "const", top)
+	} else if decl.Tok == token.VAR {
+		visit.walkValueName(decl, "var", top)
+	} else if decl.Tok == token.TYPE {
+		visit.walkValueName(decl, "type", top)
+	} else if decl.Tok == token.IMPORT {
+		visit.walkValueName(decl, "import", true)
+	}
+}
+
+func (visit nameVisitor) walkAssign(assign *ast.AssignStmt) {
+	if assign.Tok != token.DEFINE {
+		return
+	}
+
+	for _, v2 := range assign.Lhs {
+		if assignName, ok := v2.(*ast.Ident); ok {
+			visit(assignName, "var", true)
+		}
+	}
+}
+
+type camelNameRule struct {
+	switchConfig
+}
+
+func (*camelNameRule) Name() string      { return string(CamelName) }
+func (*camelNameRule) Type() ProblemType { return CamelName }
+
+func (*camelNameRule) CheckTests() bool { return true }
+
+func (*camelNameRule) CheckFile(f *File) {
+	nameVisitor(func(id *ast.Ident, kind string, local bool) {
+		if kind == "func" && f.IsTest() && isTestFunc(id.Name) {
+			// go test requires the names like TestXxx_yyy and Example_xxx
+			return
+		}
+		checkName(f, id, kind, local)
+	}).walkFile(f.AST)
+}
+
+var testFuncPrefixes = []string{"Test", "Benchmark", "Example", "Fuzz"}
+
+// isTestFunc reports whether name is a test, benchmark, example or fuzz
+// function run by go test.
+func isTestFunc(name string) bool {
+	for _, prefix := range testFuncPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimUnderscorePrefix(name string) string {
+	if name[0] == '_' {
+		return name[1:]
+	}
+	return name
+}
+
+func checkName(f *File, id *ast.Ident, kind string, notFirstCap bool) {
+	name := trimUnderscorePrefix(id.Name)
+	if name == "" {
+		return
+	}
+
+	var desc string
+	if strings.Contains(name, "_") {
+		desc = "don't use non-prefix underscores in " + kind + " name: " + id.Name + ", please use camel name"
+	} else if len(name) >= 5 && strings.ToUpper(name) == name {
+		desc = "don't use all captial letters in " + kind + " name: " + id.Name + ", please use camel name"
+	} else if notFirstCap && name[0:1] == strings.ToUpper(name[0:1]) {
+		desc = "in function ,don't use first captial letter in " + kind + " name: " + id.Name + ", please use small letter"
+	}
+	if desc != "" {
+		f.ReportFix(id.Pos(), CamelName, desc, renameLocal(f, id, kind, camelName(id.Name)))
+	}
+}
+
+// camelName converts name to a camel name starting with a small letter.
+func camelName(name string) string {
+	prefix := ""
+	if name[0] == '_' {
+		prefix = "_"
+	}
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '_' })
+	if len(parts) == 0 {
+		return name
+	}
+	for i, part := range parts {
+		if i == 0 && strings.ToUpper(part) == part {
+			parts[i] = strings.ToLower(part)
+		} else if i == 0 {
+			r, size := utf8.DecodeRuneInString(part)
+			parts[i] = string(unicode.ToLower(r)) + part[size:]
+		} else {
+			if strings.ToUpper(part) == part {
+				part = strings.ToLower(part)
+			}
+			r, size := utf8.DecodeRuneInString(part)
+			parts[i] = string(unicode.ToUpper(r)) + part[size:]
+		}
+	}
+	return prefix + strings.Join(parts, "")
+}
+
+// commonInitialisms are the initialisms should be all capital letters or
+// all small letters in the names, ref golint.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP",
+	"JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL",
+	"UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// initialismRule checks the initialisms in the names, the config is true
+// or the list of the extra initialisms.
+type initialismRule struct {
+	initialisms map[string]bool
+}
+
+func (*initialismRule) Name() string      { return string(Initialism) }
+func (*initialismRule) Type() ProblemType { return Initialism }
+
+func (*initialismRule) CheckTests() bool { return true }
+
+func (r *initialismRule) Decode(config json.RawMessage) (bool, error) {
+	var enabled bool
+	var extra []string
+	if err := json.Unmarshal(config, &enabled); err != nil {
+		if json.Unmarshal(config, &extra) != nil {
+			return false, errors.New("expect bool or list of initialisms")
+		}
+		enabled = true
+	}
+	r.initialisms = map[string]bool{}
+	for _, v := range append(extra, commonInitialisms...) {
+		if v == "" || strings.ToUpper(v) != v {
+			return false, errors.New("initialism " + v + " should be capital letters")
+		}
+		r.initialisms[v] = true
+	}
+	return enabled, nil
+}
+
+func (r *initialismRule) CheckFile(f *File) {
+	nameVisitor(func(id *ast.Ident, kind string, local bool) {
+		if kind == "func" && f.IsTest() && isTestFunc(id.Name) {
+			return
+		}
+		if strings.Contains(strings.TrimLeft(id.Name, "_"), "_") {
+			// reported by camel_name
+			return
+		}
+		name := r.fixInitialisms(id.Name)
+		if name != id.Name {
+			desc := "initialism in " + kind + " name: " + id.Name + ", please use " + name
+			f.ReportFix(id.Pos(), Initialism, desc, renameLocal(f, id, kind, name))
+		}
+	}).walkFile(f.AST)
+}
+
+// fixInitialisms converts the initialisms in the words of name to capital
+// letters, or small letters at the start of the name.
+func (r *initialismRule) fixInitialisms(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		upper := strings.ToUpper(word)
+		if !r.initialisms[upper] {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(word); i == 0 && unicode.IsLower(r) {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = upper
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits a camel name into words, like userHTTPServer into
+// user, HTTP and Server.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+var localKinds = map[string]bool{"param": true, "return param": true, "receiver": true, "var": true, "const": true}
+
+// renameLocal returns the edits renaming a local name in the function
+// declaring it, it returns nil if the renaming is not safe.
+func renameLocal(f *File, id *ast.Ident, kind, newName string) []TextEdit {
+	if !localKinds[kind] || id.Obj == nil || newName == id.Name || token.Lookup(newName).IsKeyword() {
+		return nil
+	}
+	body := enclosingFunc(f.AST, id)
+	if body == nil || nameUsed(f.AST, newName) {
+		return nil
+	}
+
+	var edits []TextEdit
+	safe := true
+	ast.Inspect(body, func(node ast.Node) bool {
+		if kv, ok := node.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == id.Name {
+				safe = false
+			}
+		}
+		if use, ok := node.(*ast.Ident); ok && use.Name == id.Name {
+			safe = safe && use.Obj == id.Obj
+			offset := f.Fset.Position(use.Pos()).Offset
+			edits = append(edits, TextEdit{Offset: offset, End: offset + len(use.Name), NewText: []byte(newName)})
+		}
+		return safe
+	})
+	if !safe {
+		return nil
+	}
+	return edits
+}
+
+// enclosingFunc returns the innermost function declaration or literal
+// containing id.
+func enclosingFunc(file *ast.File, id *ast.Ident) (fn ast.Node) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node == nil || node.Pos() > id.Pos() || node.End() <= id.Pos() {
+			return false
+		}
+		switch node.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			fn = node
+		}
+		return true
+	})
+	return fn
+}
+
+func nameUsed(file *ast.File, name string) (used bool) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok && id.Name == name {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
@@ -0,0 +1,43 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestInitialism(t *testing.T) {
+	fileName := "initialism.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"initialism": true}`).Check(fileName, file)
+	expect := []string{
+		"initialism in type name: HttpServer, please use HTTPServer",
+		"initialism in struct field name: ServerUrl, please use ServerURL",
+		"initialism in func name: parseUrl, please use parseURL",
+		"initialism in param name: rawUrl, please use rawURL",
+		"initialism in return param name: userId, please use userID",
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 5 error but ", len(ps))
+	}
+	for i, p := range ps {
+		if p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Description)
+		}
+	}
+	if len(ps[0].Fix) != 0 || len(ps[4].Fix) == 0 {
+		t.Fatal("expect only local name fixed")
+	}
+
+	ps, _ = newChecker(`{"initialism": ["KODO"]}`).Check(fileName, file)
+	if len(ps) != 6 || ps[2].Description != "initialism in struct field name: BucketKodo, please use BucketKODO" {
+		t.Fatal("expect extra initialism", ps)
+	}
+	if _, err := New([]byte(`{"initialism": ["Kodo"]}`)); err == nil {
+		t.Fatal("expect initialism error")
+	}
+
+	fileName = "initialism_test.go"
+	ps, _ = newChecker(`{"initialism": true}`).Check(fileName, readFile(fileName))
+	if len(ps) != 1 || ps[0].Description != "initialism in param name: serverUrl, please use serverURL" {
+		t.Fatal("expect the test helper checked but not the test function", ps)
+	}
+}
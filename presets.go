@@ -0,0 +1,70 @@
+package checkstyle
+
+// presets are the built-in configs, which could be referenced by the
+// extends key of a config.
+var presets = map[string]string{
+	"default": defaultPreset,
+	"strict":  strictPreset,
+	"legacy":  legacyPreset,
+}
+
+const defaultPreset = `
+# file line count limit
+file_line: 200
+# function line count limit
+func_line: 50
+# function parameter count limit
+params_num: 4
+# function return variable count limit
+results_num: 3
+# gofmt
+formated: true
+# package name should not contain _ and camel
+pkg_name: true
+# const/var/function/import name should use camel name
+camel_name: true
+# ignore file
+ignore:
+  - tmp/*
+  - src/tmp.go
+# put the check rule of error level here
+fatal:
+  - formated
+`
+
+const strictPreset = `
+# the default rules with smaller limits, all of them are errors
+extends: default
+func_line: 40
+params_num: 3
+results_num: 2
+max_indent: 4
+func_comment: true
+fatal:
+  - file_line
+  - func_line
+  - params_num
+  - results_num
+  - pkg_name
+  - camel_name
+  - max_indent
+  - func_comment
+`
+
+const legacyPreset = `
+# larger limits for the old code, only gofmt is required
+file_line: 1000
+func_line: 100
+params_num: 6
+results_num: 4
+formated: true
+pkg_name: true
+fatal:
+  - formated
+`
+
+// Preset returns the built-in config of name.
+func Preset(name string) ([]byte, bool) {
+	preset, ok := presets[name]
+	return []byte(preset), ok
+}
@@ -0,0 +1,33 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	for name := range presets {
+		config, err := ResolveConfig([]byte("extends: "+name), ".")
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if err = ValidateConfig(config); err != nil {
+			t.Fatal(name, err)
+		}
+	}
+
+	_checker := newChecker("extends: strict\nfunc_line: 60")
+	if !_checker.IsFatal(&Problem{Type: CamelName}) || !_checker.IsFatal(&Problem{Type: Formated}) {
+		t.Fatal("expect fatal of strict merged with default")
+	}
+	file := readFile("functionline.go")
+	ps, _ := _checker.Check("functionline.go", file)
+	for _, p := range ps {
+		if p.Type == FunctionLine {
+			t.Fatal("expect func_line overridden", p.Description)
+		}
+	}
+
+	if _, ok := Preset("unknown"); ok {
+		t.Fatal("expect unknown preset")
+	}
+}
@@ -0,0 +1,96 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"strconv"
+)
+
+// receiverNameRule checks the receiver names of the methods in a package.
+// The config is true, or the max length of the names.
+type receiverNameRule struct {
+	limit int
+}
+
+func (*receiverNameRule) Name() string      { return string(ReceiverName) }
+func (*receiverNameRule) Type() ProblemType { return ReceiverName }
+
+func (r *receiverNameRule) Decode(config json.RawMessage) (bool, error) {
+	var enabled bool
+	if err := json.Unmarshal(config, &enabled); err == nil {
+		return enabled, nil
+	}
+	var limit limitConfig
+	enabled, err := limit.Decode(config)
+	if _, ok := err.(*json.UnmarshalTypeError); ok {
+		err = errors.New("expect bool or max length of the names")
+	}
+	r.limit = limit.limit
+	return enabled, err
+}
+
+// receiver is the receiver name of a method.
+type receiver struct {
+	id     *ast.Ident
+	method string
+}
+
+func (r *receiverNameRule) CheckPackage(pkg *Package) {
+	receivers := map[string][]receiver{}
+	var typeNames []string
+	for _, f := range pkg.Files {
+		for _, decl := range f.AST.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			typeName := ""
+			if ok {
+				typeName = receiverTypeName(funcDecl.Recv)
+			}
+			if typeName == "" || len(funcDecl.Recv.List[0].Names) == 0 {
+				continue
+			}
+			id := funcDecl.Recv.List[0].Names[0]
+			if id.Name == "_" {
+				continue
+			}
+			r.checkName(pkg, id, typeName)
+			if _, ok := receivers[typeName]; !ok {
+				typeNames = append(typeNames, typeName)
+			}
+			receivers[typeName] = append(receivers[typeName], receiver{id, funcDecl.Name.Name})
+		}
+	}
+	for _, typeName := range typeNames {
+		checkConsistent(pkg, typeName, receivers[typeName])
+	}
+}
+
+func (r *receiverNameRule) checkName(pkg *Package, id *ast.Ident, typeName string) {
+	if id.Name == "this" || id.Name == "self" {
+		desc := "don't use generic receiver name " + id.Name + " of " + typeName + ", please use a short name of the type"
+		pkg.Report(id.Pos(), ReceiverName, desc)
+	} else if r.limit > 0 && len(id.Name) > r.limit {
+		desc := "receiver name " + id.Name + " of " + typeName + " is longer than " + strconv.Itoa(r.limit)
+		pkg.Report(id.Pos(), ReceiverName, desc)
+	}
+}
+
+// checkConsistent reports the receivers of a type which differ from the
+// name used by the most methods.
+func checkConsistent(pkg *Package, typeName string, receivers []receiver) {
+	counts := map[string]int{}
+	common := ""
+	for _, v := range receivers {
+		counts[v.id.Name]++
+		if counts[v.id.Name] > counts[common] {
+			common = v.id.Name
+		}
+	}
+	for _, v := range receivers {
+		if v.id.Name != common {
+			desc := "receiver name " + v.id.Name + " of method " + typeName + "." + v.method +
+				" should be consistent with " + common + " of the other methods"
+			pkg.Report(v.id.Pos(), ReceiverName, desc)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package checkstyle
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestReceiverName(t *testing.T) {
+	files := map[string][]byte{
+		"a.go": readFile("receiver/a.go"),
+		"b.go": readFile("receiver/b.go"),
+	}
+	ps, _ := newChecker(`{"receiver_name": 2}`).CheckPackage(files)
+	expect := []string{
+		"a.go:15: don't use generic receiver name this of counter, please use a short name of the type",
+		"a.go:15: receiver name this of method counter.Reset should be consistent with c of the other methods",
+		"b.go:3: receiver name cnt of counter is longer than 2",
+		"b.go:13: receiver name buf of buffer is longer than 2",
+		"b.go:3: receiver name cnt of method counter.Value should be consistent with c of the other methods",
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 5 error but ", ps)
+	}
+	for i, p := range ps {
+		if p.Position.Filename+":"+strconv.Itoa(p.Position.Line)+": "+p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Position, p.Description)
+		}
+	}
+
+	ps, _ = newChecker(`{"receiver_name": true}`).CheckPackage(files)
+	if len(ps) != 3 {
+		t.Fatal("expect no length check but ", ps)
+	}
+	files["a_test.go"] = readFile("receiver/a_test.go")
+	ps, _ = newChecker(`{"tests": {"receiver_name": true}}`).CheckPackage(files)
+	if len(ps) != 1 || ps[0].Position.Filename != "a_test.go" || ps[0].Position.Line != 3 {
+		t.Fatal("expect the test receiver checked by the tests block but ", ps)
+	}
+	ps, _ = newChecker(`{"receiver_name": true, "tests": {}}`).CheckPackage(files)
+	if len(ps) != 4 || ps[2].Position.Filename != "a_test.go" {
+		t.Fatal("expect the test receiver checked apart but ", ps)
+	}
+	if _, err := New([]byte(`{"receiver_name": "short"}`)); err == nil {
+		t.Fatal("expect receiver_name config error")
+	}
+}
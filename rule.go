@@ -0,0 +1,128 @@
+package checkstyle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/ast"
+)
+
+// Rule is a style check run by the checker. The rule is created by the
+// factory given to Register, and configured from the value of its name
+// in the config.
+type Rule interface {
+	// Name returns the config key of the rule.
+	Name() string
+	// Type returns the type of the problems reported by the rule.
+	Type() ProblemType
+	// Decode reads the rule config, it returns false if the rule is disabled.
+	Decode(config json.RawMessage) (bool, error)
+}
+
+// FileRule is a rule called once for each file.
+type FileRule interface {
+	Rule
+	CheckFile(f *File)
+}
+
+// NodeRule is a rule called for each node of the file syntax tree.
+type NodeRule interface {
+	Rule
+	CheckNode(f *File, node ast.Node)
+}
+
+// PackageRule is a rule called once for each package, after the rules
+// checking its files.
+type PackageRule interface {
+	Rule
+	CheckPackage(pkg *Package)
+}
+
+// TestRule is implemented by rules which also check _test.go files, the
+// other rules skip them unless the config has a tests block.
+type TestRule interface {
+	Rule
+	CheckTests() bool
+}
+
+var registry []func() Rule
+
+// Register makes a rule available to the checkers created by New.
+// It is intended to be called from the init function of the package
+// defining the rule, and panics if the rule name is already registered.
+func Register(factory func() Rule) {
+	name := factory().Name()
+	for _, v := range registry {
+		if v().Name() == name {
+			panic("checkstyle: Register called twice for rule " + name)
+		}
+	}
+	registry = append(registry, factory)
+}
+
+func newRules(config []byte) ([]Rule, error) {
+	var raws map[string]json.RawMessage
+	err := json.Unmarshal(config, &raws)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	for _, factory := range registry {
+		rule := factory()
+		raw, ok := raws[rule.Name()]
+		if !ok {
+			continue
+		}
+		enabled, err := rule.Decode(raw)
+		if err != nil {
+			return nil, newConfigError(rule.Name(), err)
+		}
+		if enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func checksTests(rule Rule) bool {
+	r, ok := rule.(TestRule)
+	return ok && r.CheckTests()
+}
+
+// ConfigError records an invalid value in the config.
+type ConfigError struct {
+	Key string
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return "checkstyle: config " + e.Key + ": " + e.Err.Error()
+}
+
+// decodeStrict decodes the object config of a rule into v, the unknown
+// keys are errors.
+func decodeStrict(config json.RawMessage, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(config))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+type limitConfig struct {
+	limit int
+}
+
+func (c *limitConfig) Decode(config json.RawMessage) (bool, error) {
+	err := json.Unmarshal(config, &c.limit)
+	if err == nil && c.limit < 0 {
+		err = errors.New("limit " + string(config) + " is negative")
+	}
+	return c.limit > 0, err
+}
+
+type switchConfig struct{}
+
+func (c *switchConfig) Decode(config json.RawMessage) (bool, error) {
+	var enabled bool
+	err := json.Unmarshal(config, &enabled)
+	return enabled, err
+}
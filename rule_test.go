@@ -0,0 +1,153 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/types"
+	"testing"
+)
+
+const TodoName ProblemType = "todo_name"
+
+type todoNameRule struct {
+	Word string `json:"word"`
+}
+
+func (*todoNameRule) Name() string      { return string(TodoName) }
+func (*todoNameRule) Type() ProblemType { return TodoName }
+
+func (r *todoNameRule) Decode(config json.RawMessage) (bool, error) {
+	err := json.Unmarshal(config, r)
+	return r.Word != "", err
+}
+
+func (r *todoNameRule) CheckNode(f *File, node ast.Node) {
+	if id, ok := node.(*ast.Ident); ok && id.Name == r.Word {
+		f.Report(id.Pos(), TodoName, "don't use name "+r.Word)
+	}
+}
+
+const PackageFiles ProblemType = "pkg_files"
+
+type pkgFilesRule struct {
+	limitConfig
+}
+
+func (*pkgFilesRule) Name() string      { return string(PackageFiles) }
+func (*pkgFilesRule) Type() ProblemType { return PackageFiles }
+
+func (r *pkgFilesRule) CheckPackage(pkg *Package) {
+	if len(pkg.Files) > r.limit {
+		last := pkg.Files[len(pkg.Files)-1].AST
+		pkg.Report(last.Name.Pos(), PackageFiles, "package "+pkg.Name+" has too many files")
+	}
+}
+
+const ErrorVar ProblemType = "error_var"
+
+type errorVarRule struct {
+	switchConfig
+}
+
+func (*errorVarRule) Name() string      { return string(ErrorVar) }
+func (*errorVarRule) Type() ProblemType { return ErrorVar }
+
+func (*errorVarRule) CheckNode(f *File, node ast.Node) {
+	id, ok := node.(*ast.Ident)
+	if !ok || f.TypesInfo == nil || f.TypesInfo.Defs[id] == nil {
+		return
+	}
+	if types.Identical(f.TypesInfo.Defs[id].Type(), types.Universe.Lookup("error").Type()) {
+		f.Report(id.Pos(), ErrorVar, "error var "+id.Name)
+	}
+}
+
+func (*errorVarRule) CheckPackage(pkg *Package) {
+	if len(pkg.TypeErrors) != 0 {
+		pkg.Report(pkg.Files[0].AST.Name.Pos(), ErrorVar, pkg.TypeErrors[0].Error())
+	}
+}
+
+func init() {
+	Register(func() Rule { return &todoNameRule{} })
+	Register(func() Rule { return &pkgFilesRule{} })
+	Register(func() Rule { return &errorVarRule{} })
+}
+
+func TestRegister(t *testing.T) {
+	fileName := "params_num.go"
+	file := readFile(fileName)
+	ps, err := newChecker(`{"todo_name": {"word": "hello2"}}`).Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Type != TodoName || ps[0].Position.Line != 11 {
+		t.Fatal("expect an error from the registered rule")
+	}
+
+	_, err = New([]byte(`{"todo_name": 1}`))
+	if _, ok := err.(*ConfigError); !ok {
+		t.Fatal("expect config error but ", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expect panic on duplicated rule")
+		}
+	}()
+	Register(func() Rule { return &todoNameRule{} })
+}
+
+func TestCheckPackage(t *testing.T) {
+	files := map[string][]byte{
+		"fileline.go":      readFile("fileline.go"),
+		"functionline.go":  readFile("functionline.go"),
+		"fileline_test.go": readFile("fileline.go"),
+		"caps_pkg.go":      readFile("caps_pkg.go"),
+	}
+	ps, err := newChecker(`{"pkg_files": 1, "file_line": 9}`).CheckPackage(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[0].Type != FileLine || ps[1].Type != PackageFiles {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	if ps[0].Position.Filename != "functionline.go" || ps[1].Position.Filename != "functionline.go" {
+		t.Fatal("file name is not correct")
+	}
+
+	ps, _ = newChecker(`{"pkg_files": 2}`).CheckPackage(files)
+	if len(ps) != 0 {
+		t.Fatal("expect test files and other packages not counted")
+	}
+}
+
+func TestTypeCheck(t *testing.T) {
+	fileName := "types.go"
+	file := readFile(fileName)
+	ps, err := newChecker(`{"error_var": true}`).Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 0 {
+		t.Fatal("expect no type info without type_check")
+	}
+
+	_checker := newChecker(`{"error_var": true, "type_check": true}`)
+	ps, err = _checker.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Position.Line != 15 {
+		t.Fatal("expect 1 error but ", len(ps))
+	}
+
+	fileName = "types_error.go"
+	ps, err = _checker.Check(fileName, readFile(fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Position.Line != 1 {
+		t.Fatal("expect type errors reported but ", len(ps))
+	}
+}
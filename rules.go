@@ -0,0 +1,326 @@
+package checkstyle
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(func() Rule { return &formatRule{} })
+	Register(func() Rule { return &fileLineRule{} })
+	Register(func() Rule { return &pkgNameRule{} })
+	Register(func() Rule { return &funcLineRule{} })
+	Register(func() Rule { return &paramsNumRule{} })
+	Register(func() Rule { return &resultsNumRule{} })
+	Register(func() Rule { return &camelNameRule{} })
+	Register(func() Rule { return &funcCommentRule{} })
+	Register(func() Rule { return &maxIndentRule{} })
+	Register(func() Rule { return &cyclomaticRule{} })
+	Register(func() Rule { return &cognitiveRule{} })
+	Register(func() Rule { return &lineLengthRule{} })
+	Register(func() Rule { return &receiverNameRule{} })
+	Register(func() Rule { return &initialismRule{} })
+	Register(func() Rule { return &errorStringRule{} })
+	Register(func() Rule { return &errorNameRule{} })
+	Register(func() Rule { return &contextFirstRule{} })
+	Register(func() Rule { return &errorLastRule{} })
+	Register(func() Rule { return &boolParamsRule{} })
+	Register(func() Rule { return &nakedReturnRule{} })
+	Register(func() Rule { return &importGroupRule{} })
+	Register(func() Rule { return &importsRule{} })
+}
+
+type formatRule struct {
+	switchConfig
+}
+
+func (*formatRule) Name() string      { return string(Formated) }
+func (*formatRule) Type() ProblemType { return Formated }
+func (*formatRule) CheckTests() bool  { return true }
+
+func (*formatRule) CheckFile(f *File) {
+	src, err := format.Source(f.Src)
+	if err != nil {
+		f.Report(f.AST.Pos(), ParseError, "format fail: "+err.Error())
+		return
+	}
+	if len(src) != len(f.Src) || bytes.Compare(src, f.Src) != 0 {
+		fix := []TextEdit{{Offset: 0, End: len(f.Src), NewText: src}}
+		f.ReportFix(f.AST.Pos(), Formated, "source is not formated", fix)
+	}
+}
+
+type fileLineRule struct {
+	limitConfig
+}
+
+func (*fileLineRule) Name() string      { return string(FileLine) }
+func (*fileLineRule) Type() ProblemType { return FileLine }
+
+func (r *fileLineRule) CheckFile(f *File) {
+	lineCount := f.Fset.File(f.AST.Pos()).LineCount()
+	if lineCount > r.limit {
+		desc := strconv.Itoa(lineCount) + " lines more than " + strconv.Itoa(r.limit)
+		f.Report(f.AST.End(), FileLine, desc)
+	}
+}
+
+type pkgNameRule struct {
+	switchConfig
+}
+
+func (*pkgNameRule) Name() string      { return string(PackageName) }
+func (*pkgNameRule) Type() ProblemType { return PackageName }
+
+func (*pkgNameRule) CheckFile(f *File) {
+	//ref "http://golang.org/doc/effective_go.html#package-names"
+	pkgName := f.AST.Name.Name
+	var desc string
+	if strings.Contains(pkgName, "_") {
+		suggestName := strings.Replace(pkgName, "_", "/", -1)
+		desc = "don't use an underscore in package name, " + pkgName + " should be " + suggestName
+	} else if strings.ToLower(pkgName) != pkgName {
+		desc = "don't use capital letters in package name: " + pkgName
+	}
+	if desc != "" {
+		f.Report(f.AST.Name.Pos(), PackageName, desc)
+	}
+}
+
+type funcLineRule struct {
+	limitConfig
+}
+
+func (*funcLineRule) Name() string      { return string(FunctionLine) }
+func (*funcLineRule) Type() ProblemType { return FunctionLine }
+
+// funcLines returns the body lines of a function, which are the lines
+// between the first and the last.
+func funcLines(f *File, node ast.Node) int {
+	startLine := f.Fset.Position(node.Pos()).Line
+	endLine := f.Fset.Position(node.End()).Line
+	return endLine - startLine
+}
+
+func (r *funcLineRule) CheckNode(f *File, node ast.Node) {
+	funcDecl, ok := node.(*ast.FuncDecl)
+	if !ok {
+		return
+	}
+	lineCount := funcLines(f, funcDecl)
+	if lineCount > r.limit {
+		desc := "func " + funcDecl.Name.Name + "() body lines num " + strconv.Itoa(lineCount) +
+			" more than " + strconv.Itoa(r.limit)
+		f.Report(funcDecl.Pos(), FunctionLine, desc)
+	}
+}
+
+// eachFuncType calls fn with the function types declared by node, which are
+// the type of a function declaration or the methods of an interface.
+func eachFuncType(node ast.Node, fn func(fType *ast.FuncType, funcName string)) {
+	switch decl := node.(type) {
+	case *ast.FuncDecl:
+		fn(decl.Type, decl.Name.Name)
+	case *ast.InterfaceType:
+		if decl.Methods == nil {
+			return
+		}
+		for _, v := range decl.Methods.List {
+			if fType, ok := v.Type.(*ast.FuncType); ok {
+				fn(fType, v.Names[0].Name)
+			}
+		}
+	}
+}
+
+type paramsNumRule struct {
+	limitConfig
+}
+
+func (*paramsNumRule) Name() string      { return string(ParamsNum) }
+func (*paramsNumRule) Type() ProblemType { return ParamsNum }
+
+func (r *paramsNumRule) CheckNode(f *File, node ast.Node) {
+	eachFuncType(node, func(fType *ast.FuncType, funcName string) {
+		params := fType.Params
+		if params != nil && params.NumFields() > r.limit {
+			desc := "func " + funcName + "() params num " + strconv.Itoa(params.NumFields()) +
+				"  more than " + strconv.Itoa(r.limit)
+			f.Report(params.Pos(), ParamsNum, desc)
+		}
+	})
+}
+
+type resultsNumRule struct {
+	limitConfig
+}
+
+func (*resultsNumRule) Name() string      { return string(ResultsNum) }
+func (*resultsNumRule) Type() ProblemType { return ResultsNum }
+
+func (r *resultsNumRule) CheckNode(f *File, node ast.Node) {
+	if funcDecl, ok := node.(*ast.FuncDecl); ok && funcDecl.Body == nil {
+		desc := "func " + funcDecl.Name.Name + " expected block '{}'"
+		f.Report(funcDecl.Pos(), ResultsNum, desc)
+	}
+	eachFuncType(node, func(fType *ast.FuncType, funcName string) {
+		results := fType.Results
+		if results != nil && results.NumFields() > r.limit {
+			desc := "func " + funcName + "() results num " + strconv.Itoa(results.NumFields()) +
+				"  more than " + strconv.Itoa(r.limit)
+			f.Report(results.Pos(), ResultsNum, desc)
+		}
+	})
+}
+
+type indentVisitor struct {
+	depth   int
+	deepest *indentVisitor
+	stmt    ast.Stmt
+}
+
+func (v *indentVisitor) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case nil, *ast.CaseClause, *ast.CommClause:
+	case *ast.BlockStmt:
+		return &indentVisitor{depth: v.depth + 1, deepest: v.deepest}
+	case ast.Stmt:
+		if v.depth > v.deepest.depth {
+			v.deepest.depth = v.depth
+			v.deepest.stmt = n
+		}
+	}
+	return v
+}
+
+type maxIndentRule struct {
+	limitConfig
+}
+
+func (*maxIndentRule) Name() string      { return string(MaxIndent) }
+func (*maxIndentRule) Type() ProblemType { return MaxIndent }
+
+func (r *maxIndentRule) checkBody(f *File, body *ast.BlockStmt, funcName string) {
+	if body == nil {
+		return
+	}
+	deepest := &indentVisitor{}
+	ast.Walk(&indentVisitor{deepest: deepest}, body)
+	if deepest.depth > r.limit {
+		desc := "func " + funcName + "() indent depth " + strconv.Itoa(deepest.depth) +
+			" more than " + strconv.Itoa(r.limit)
+		f.Report(deepest.stmt.Pos(), MaxIndent, desc)
+	}
+}
+
+func (r *maxIndentRule) CheckFile(f *File) {
+	for _, v := range f.AST.Decls {
+		switch decl := v.(type) {
+		case *ast.FuncDecl:
+			r.checkBody(f, decl.Body, decl.Name.Name)
+		case *ast.GenDecl:
+			ast.Inspect(decl, func(node ast.Node) bool {
+				if lit, ok := node.(*ast.FuncLit); ok {
+					r.checkBody(f, lit.Body, "literal")
+					return false
+				}
+				return true
+			})
+		}
+	}
+}
+
+type funcCommentRule struct {
+	switchConfig
+}
+
+func (*funcCommentRule) Name() string      { return string(FuncComment) }
+func (*funcCommentRule) Type() ProblemType { return FuncComment }
+
+func receiverTypeName(recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) == 0 {
+		return ""
+	}
+	expr := recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func checkComment(f *File, doc *ast.CommentGroup, id *ast.Ident, kind string) {
+	//ref "https://golang.org/doc/effective_go.html#commentary"
+	var desc string
+	text := doc.Text()
+	if strings.TrimSpace(text) == "" {
+		desc = "exported " + kind + " " + id.Name + " should have comment"
+	} else if kind == "type" {
+		for _, article := range []string{"A ", "An ", "The "} {
+			text = strings.TrimPrefix(text, article)
+		}
+	}
+	if desc == "" && !strings.HasPrefix(text, id.Name+" ") && strings.TrimSpace(text) != id.Name {
+		desc = "comment on exported " + kind + " " + id.Name + " should be of the form \"" + id.Name + " ...\""
+	}
+	if desc != "" {
+		f.Report(id.Pos(), FuncComment, desc)
+	}
+}
+
+func checkSpecComment(f *File, decl *ast.GenDecl, specDoc *ast.CommentGroup, names []*ast.Ident) {
+	doc := specDoc
+	if !decl.Lparen.IsValid() {
+		doc = decl.Doc
+	} else if doc == nil && decl.Doc != nil {
+		// a grouped declaration could be documented by the comment of the group
+		return
+	}
+	for _, name := range names {
+		if name.IsExported() {
+			checkComment(f, doc, name, decl.Tok.String())
+			return
+		}
+	}
+}
+
+func (*funcCommentRule) checkFuncDecl(f *File, funcDecl *ast.FuncDecl) {
+	if !funcDecl.Name.IsExported() {
+		return
+	}
+	kind := "func"
+	if funcDecl.Recv != nil {
+		if !ast.IsExported(receiverTypeName(funcDecl.Recv)) {
+			return
+		}
+		kind = "method"
+	}
+	checkComment(f, funcDecl.Doc, funcDecl.Name, kind)
+}
+
+func (r *funcCommentRule) CheckFile(f *File) {
+	for _, v := range f.AST.Decls {
+		switch decl := v.(type) {
+		case *ast.FuncDecl:
+			r.checkFuncDecl(f, decl)
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				break
+			}
+			for _, spec := range decl.Specs {
+				switch s := spec.(type) {
+				case *ast.ValueSpec:
+					checkSpecComment(f, decl, s.Doc, s.Names)
+				case *ast.TypeSpec:
+					checkSpecComment(f, decl, s.Doc, []*ast.Ident{s.Name})
+				}
+			}
+		}
+	}
+}
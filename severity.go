@@ -0,0 +1,77 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Severity is the level of a problem, it is configured per rule by the
+// severity map in the config.
+type Severity int
+
+const (
+	// SeverityOff disables a rule, problems are never reported with it.
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"off", "info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity returns the severity of name, which is one of off, info,
+// warning and error.
+func ParseSeverity(name string) (Severity, error) {
+	for i, v := range severityNames {
+		if v == name {
+			return Severity(i), nil
+		}
+	}
+	return SeverityOff, errors.New("unknown severity " + name)
+}
+
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+	*s, err = ParseSeverity(name)
+	return err
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Severity returns the severity of the problems of type t. It is the value
+// in the severity map of the config, or error for the types in the fatal
+// list, otherwise warning.
+func (c *checker) Severity(t ProblemType) Severity {
+	if t == ParseError {
+		return SeverityError
+	}
+	if s, ok := c.Severities[string(t)]; ok {
+		return s
+	}
+	for _, v := range c.Fatal {
+		if v == string(t) {
+			return SeverityError
+		}
+	}
+	return SeverityWarning
+}
+
+func (c *checker) IsFatal(p *Problem) bool {
+	if p.Severity != SeverityOff {
+		return p.Severity >= SeverityError
+	}
+	return c.Severity(p.Type) >= SeverityError
+}
@@ -0,0 +1,47 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	config := `{
+		"camel_name": true,
+		"pkg_name": true,
+		"params_num": 1,
+		"results_num": 1,
+		"fatal": ["camel_name", "pkg_name"],
+		"severity": {"pkg_name": "info", "results_num": "off"}
+	}`
+	_checker := newChecker(config)
+	if _checker.Severity(CamelName) != SeverityError || _checker.Severity(PackageName) != SeverityInfo {
+		t.Fatal("expect severity map takes precedence over fatal")
+	}
+	if _checker.Severity(ParamsNum) != SeverityWarning || _checker.Severity(ParseError) != SeverityError {
+		t.Fatal("expect default severity")
+	}
+
+	fileName := "underscore_pkg.go"
+	ps, err := _checker.Check(fileName, readFile(fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 1 || ps[0].Severity != SeverityInfo || _checker.IsFatal(&ps[0]) {
+		t.Fatal("expect an info problem")
+	}
+
+	fileName = "results_num.go"
+	ps, _ = _checker.Check(fileName, readFile(fileName))
+	for _, p := range ps {
+		if p.Type == ResultsNum {
+			t.Fatal("expect rule turned off")
+		}
+		if p.Type == ParamsNum && p.Severity != SeverityWarning {
+			t.Fatal("expect a warning problem")
+		}
+	}
+
+	if _, err = New([]byte(`{"severity": {"pkg_name": "fatal"}}`)); err == nil {
+		t.Fatal("expect unknown severity error")
+	}
+}
@@ -0,0 +1,177 @@
+package checkstyle
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// fieldTypes returns the type of each name in the field list, or each
+// field if the names are omitted.
+func fieldTypes(fields *ast.FieldList) []ast.Expr {
+	if fields == nil {
+		return nil
+	}
+	var exprs []ast.Expr
+	for _, field := range fields.List {
+		exprs = append(exprs, field.Type)
+		for i := 1; i < len(field.Names); i++ {
+			exprs = append(exprs, field.Type)
+		}
+	}
+	return exprs
+}
+
+// isType reports whether expr is the type pkgPath.name, or the type name
+// in the universe if pkgPath is empty. It checks the type information if
+// type_check is enabled, otherwise the syntax, which could be wrong if the
+// package or the type is renamed.
+func isType(f *File, expr ast.Expr, pkgPath, name string) bool {
+	if f.TypesInfo != nil && f.Package.Types != nil && f.TypesInfo.TypeOf(expr) != nil {
+		t := lookupType(f.Package.Types, pkgPath, name)
+		return t != nil && types.Identical(f.TypesInfo.TypeOf(expr), t)
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == pkgPath && sel.Sel.Name == name
+	}
+	id, ok := expr.(*ast.Ident)
+	return ok && pkgPath == "" && id.Name == name
+}
+
+// lookupType returns the type pkgPath.name imported by pkg.
+func lookupType(pkg *types.Package, pkgPath, name string) types.Type {
+	scope := types.Universe
+	for _, v := range pkg.Imports() {
+		if v.Path() == pkgPath {
+			scope = v.Scope()
+		}
+	}
+	if pkgPath != "" && scope == types.Universe {
+		return nil
+	}
+	if obj, ok := scope.Lookup(name).(*types.TypeName); ok {
+		return obj.Type()
+	}
+	return nil
+}
+
+func isBool(f *File, expr ast.Expr) bool {
+	if f.TypesInfo != nil {
+		if t := f.TypesInfo.TypeOf(expr); t != nil {
+			basic, ok := t.Underlying().(*types.Basic)
+			return ok && basic.Kind() == types.Bool
+		}
+	}
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "bool"
+}
+
+type contextFirstRule struct {
+	switchConfig
+}
+
+func (*contextFirstRule) Name() string      { return string(ContextFirst) }
+func (*contextFirstRule) Type() ProblemType { return ContextFirst }
+
+func (*contextFirstRule) CheckNode(f *File, node ast.Node) {
+	eachFuncType(node, func(fType *ast.FuncType, funcName string) {
+		for i, expr := range fieldTypes(fType.Params) {
+			if i != 0 && isType(f, expr, "context", "Context") {
+				f.Report(expr.Pos(), ContextFirst, "context.Context should be the first param of func "+funcName+"()")
+				return
+			}
+		}
+	})
+}
+
+type errorLastRule struct {
+	switchConfig
+}
+
+func (*errorLastRule) Name() string      { return string(ErrorLast) }
+func (*errorLastRule) Type() ProblemType { return ErrorLast }
+
+func (*errorLastRule) CheckNode(f *File, node ast.Node) {
+	eachFuncType(node, func(fType *ast.FuncType, funcName string) {
+		results := fieldTypes(fType.Results)
+		for i, expr := range results {
+			if i != len(results)-1 && isType(f, expr, "", "error") {
+				f.Report(expr.Pos(), ErrorLast, "error should be the last result of func "+funcName+"()")
+				return
+			}
+		}
+	})
+}
+
+// boolParamsRule reports the functions with more consecutive bool params
+// than the limit, the flag arguments are hard to read at the call sites.
+type boolParamsRule struct {
+	limitConfig
+}
+
+func (*boolParamsRule) Name() string      { return string(BoolParams) }
+func (*boolParamsRule) Type() ProblemType { return BoolParams }
+
+func (r *boolParamsRule) CheckNode(f *File, node ast.Node) {
+	eachFuncType(node, func(fType *ast.FuncType, funcName string) {
+		count := 0
+		params := fieldTypes(fType.Params)
+		for i, expr := range params {
+			if !isBool(f, expr) {
+				count = 0
+				continue
+			}
+			count++
+			if count > r.limit && (i == len(params)-1 || !isBool(f, params[i+1])) {
+				desc := "func " + funcName + "() has " + strconv.Itoa(count) + " consecutive bool params more than " +
+					strconv.Itoa(r.limit)
+				f.Report(params[i-count+1].Pos(), BoolParams, desc)
+				return
+			}
+		}
+	})
+}
+
+// nakedReturnRule reports the returns without values in the functions with
+// named results, whose body lines are more than the limit.
+type nakedReturnRule struct {
+	limitConfig
+}
+
+func (*nakedReturnRule) Name() string      { return string(NakedReturn) }
+func (*nakedReturnRule) Type() ProblemType { return NakedReturn }
+
+func (r *nakedReturnRule) CheckNode(f *File, node ast.Node) {
+	var fType *ast.FuncType
+	name, body := funcBody(node)
+	switch fn := node.(type) {
+	case *ast.FuncDecl:
+		fType = fn.Type
+	case *ast.FuncLit:
+		fType = fn.Type
+	}
+	if body == nil || fType.Results.NumFields() == 0 || len(fType.Results.List[0].Names) == 0 {
+		return
+	}
+	lineCount := funcLines(f, node)
+	if lineCount <= r.limit {
+		return
+	}
+	var names []string
+	for _, field := range fType.Results.List {
+		for _, id := range field.Names {
+			names = append(names, id.Name)
+		}
+	}
+	desc := "naked return in func " + name + " with named results " + strings.Join(names, ", ") +
+		", body lines num " + strconv.Itoa(lineCount) + " more than " + strconv.Itoa(r.limit)
+	ast.Inspect(body, func(node ast.Node) bool {
+		if ret, ok := node.(*ast.ReturnStmt); ok && len(ret.Results) == 0 {
+			f.Report(ret.Pos(), NakedReturn, desc)
+		}
+		_, isLit := node.(*ast.FuncLit)
+		return !isLit
+	})
+}
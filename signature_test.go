@@ -0,0 +1,50 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestSignature(t *testing.T) {
+	fileName := "signature.go"
+	file := readFile(fileName)
+	config := `{"context_first": true, "error_last": true, "bool_params": 2}`
+	ps, _ := newChecker(config).Check(fileName, file)
+	expect := []string{
+		"context.Context should be the first param of func Get()",
+		"error should be the last result of func Get()",
+		"context.Context should be the first param of func open()",
+		"func open() has 3 consecutive bool params more than 2",
+	}
+	if len(ps) != len(expect) {
+		t.Fatal("expect 4 error but ", ps)
+	}
+	for i, p := range ps {
+		if p.Description != expect[i] {
+			t.Fatal("unexpected problem", p.Description)
+		}
+	}
+
+	// the alias of error and the named bool type are found by type check
+	ps, _ = newChecker(`{"type_check": true, "error_last": true, "bool_params": 2}`).Check(fileName, file)
+	if len(ps) != 4 || ps[1].Position.Line != 19 || ps[3].Position.Line != 23 {
+		t.Fatal("expect 4 error but ", ps)
+	}
+}
+
+func TestNakedReturn(t *testing.T) {
+	fileName := "naked_return.go"
+	file := readFile(fileName)
+	ps, _ := newChecker(`{"naked_return": 2}`).Check(fileName, file)
+	if len(ps) != 2 {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+	expect := "naked return in func parse() with named results n, err, body lines num 12 more than 2"
+	if ps[0].Position.Line != 10 || ps[0].Description != expect || ps[1].Position.Line != 18 {
+		t.Fatal("unexpected problem", ps[0].Position, ps[0].Description)
+	}
+
+	ps, _ = newChecker(`{"naked_return": 1}`).Check(fileName, file)
+	if len(ps) != 4 || ps[3].Description != "naked return in func literal with named results ok, body lines num 2 more than 1" {
+		t.Fatal("expect 4 error but ", ps)
+	}
+}
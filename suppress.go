@@ -0,0 +1,131 @@
+package checkstyle
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const (
+	ignoreDirective     = "//checkstyle:ignore"
+	fileIgnoreDirective = "//checkstyle:file-ignore"
+)
+
+// suppression is a checkstyle:ignore comment, it silences the problems of
+// types between the lines from and to.
+type suppression struct {
+	types    []string
+	reason   string
+	from, to int
+}
+
+func (s *suppression) match(p *Problem) bool {
+	if p.Position.Line < s.from || (s.to != 0 && p.Position.Line > s.to) {
+		return false
+	}
+	for _, t := range s.types {
+		if t == "all" || t == string(p.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSuppression(f *File, c *ast.Comment) *suppression {
+	var text string
+	s := &suppression{}
+	if strings.HasPrefix(c.Text, fileIgnoreDirective+" ") {
+		text = c.Text[len(fileIgnoreDirective):]
+		s.from = 1
+	} else if strings.HasPrefix(c.Text, ignoreDirective+" ") {
+		text = c.Text[len(ignoreDirective):]
+		s.from = f.Fset.PositionFor(c.Pos(), false).Line
+		s.to = s.from
+	} else {
+		return nil
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil
+	}
+	s.types = strings.Split(fields[0], ",")
+	s.reason = strings.Join(fields[1:], " ")
+	return s
+}
+
+// docSuppressions extends the suppressions in the doc comment of a
+// declaration to the whole declaration.
+func docSuppressions(f *File, lines map[int]*suppression) {
+	ast.Inspect(f.AST, func(node ast.Node) bool {
+		var doc *ast.CommentGroup
+		switch decl := node.(type) {
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.GenDecl:
+			doc = decl.Doc
+		case *ast.TypeSpec:
+			doc = decl.Doc
+		case *ast.ValueSpec:
+			doc = decl.Doc
+		case *ast.Field:
+			doc = decl.Doc
+		}
+		if doc == nil {
+			return true
+		}
+		for _, c := range doc.List {
+			if s, ok := lines[f.Fset.PositionFor(c.Pos(), false).Line]; ok {
+				s.to = f.Fset.PositionFor(node.End(), false).Line
+			}
+		}
+		return true
+	})
+}
+
+func (f *File) suppressions(requireReason bool) []*suppression {
+	var ss []*suppression
+	lines := map[int]*suppression{}
+	for _, cg := range f.AST.Comments {
+		for _, c := range cg.List {
+			s := parseSuppression(f, c)
+			if s == nil {
+				continue
+			}
+			if requireReason && s.reason == "" {
+				f.Report(c.Pos(), Suppress, "suppression comment needs a reason: "+c.Text)
+				continue
+			}
+			if s.to != 0 {
+				lines[s.from] = s
+			}
+			ss = append(ss, s)
+		}
+	}
+	if len(lines) != 0 {
+		docSuppressions(f, lines)
+	}
+	return ss
+}
+
+// suppress removes the problems silenced by checkstyle:ignore comments.
+func (f *File) suppress(requireReason bool) {
+	ss := f.suppressions(requireReason)
+	if len(ss) == 0 {
+		return
+	}
+	ps := f.problems[:0]
+	for i := range f.problems {
+		if !isSuppressed(ss, &f.problems[i]) {
+			ps = append(ps, f.problems[i])
+		}
+	}
+	f.problems = ps
+}
+
+func isSuppressed(ss []*suppression, p *Problem) bool {
+	for _, s := range ss {
+		if s.match(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestSuppress(t *testing.T) {
+	fileName := "suppress.go"
+	file := readFile(fileName)
+	_checker := newChecker(`{"camel_name": true, "params_num": 3, "func_line": 2}`)
+	ps, err := _checker.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[0].Position.Line != 11 || ps[1].Position.Line != 19 {
+		t.Fatal("expect 2 error but ", len(ps))
+	}
+
+	_checker = newChecker(`{"camel_name": true, "params_num": 3, "suppress_reason": true}`)
+	ps, err = _checker.Check(fileName, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 6 {
+		t.Fatal("expect 6 error but ", len(ps))
+	}
+	if ps[5].Type != Suppress || ps[5].Position.Line != 13 {
+		t.Fatal("expect suppression without reason reported")
+	}
+}
@@ -0,0 +1,16 @@
+package testdata
+
+import "fmt"
+
+type point struct {
+	X_Y int
+}
+
+func hello(User_Name string, Count int) (Total_Num int) {
+	MAX_SIZE := 10
+	fmt.Println(User_Name, MAX_SIZE)
+	p := point{X_Y: 1}
+	X_Y := p.X_Y
+	Total_Num = Count + X_Y
+	return
+}
@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	"io"
+)
+
+type tree struct {
+	left, right *tree
+	closer      io.Closer
+}
+
+func (t *tree) Close() error {
+	if t == nil {
+		return nil
+	}
+	return t.closer.Close()
+}
+
+func (t *tree) walk(visit func(*tree) bool) bool {
+	if t == nil {
+		return true
+	}
+	return visit(t) && t.left.walk(visit) && t.right.walk(visit)
+}
+
+func sum(items [][]int, limit int) (total int) {
+	for _, row := range items {
+		for _, v := range row {
+			if v > 0 && v < limit || v == -1 {
+				total += v
+			} else if v == 0 {
+				continue
+			} else {
+				break
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,30 @@
+package testdata
+
+func simple(a int) int {
+	return a
+}
+
+func complex(a, b int, c chan int) int {
+	if a > 0 && b > 0 {
+		return 1
+	}
+	for i := 0; i < a; i++ {
+		switch i {
+		case 1, 2:
+			b++
+		case 3:
+			b--
+		default:
+		}
+	}
+	select {
+	case v := <-c:
+		b += v
+	default:
+	}
+	f := func() bool {
+		return a > 1 || b > 1 || a == b
+	}
+	_ = f
+	return b
+}
@@ -0,0 +1,28 @@
+package testdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound  = errors.New("not found")
+	NotFoundErr  = errors.New("Not found.")
+	invalidError = fmt.Errorf("invalid URL: %s", "a")
+	errClosed    = errors.New("closed:")
+	errBlank     = errors.New(" ")
+	errNewline   = errors.New("\n")
+	errEscaped   = errors.New("\u0046oo")
+)
+
+type timeout struct{}
+
+func (timeout) Error() string {
+	return "timeout"
+}
+
+type TimeoutError struct{}
+
+func (*TimeoutError) Error() string {
+	return fmt.Errorf("Timeout of %s\n", "a").Error()
+}
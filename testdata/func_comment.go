@@ -0,0 +1,50 @@
+package testdata
+
+// Hello says hello.
+func Hello() {
+}
+
+func World() {
+}
+
+// says nothing.
+func Nothing() {
+}
+
+func hidden() {
+}
+
+// A Greeter greets.
+type Greeter struct{}
+
+// Greet greets.
+func (g *Greeter) Greet() {
+}
+
+func (g *Greeter) Wave() {
+}
+
+type greeter struct{}
+
+func (g *greeter) Wave() {
+}
+
+// Version is the version.
+const Version = "1.0"
+
+// Sizes of the greeting.
+const (
+	Small = iota
+	Large
+)
+
+var (
+	// Name is the name.
+	Name = "a"
+	// the age.
+	Age     = 1
+	Unknown = 2
+	private = 3
+)
+
+type Shape int
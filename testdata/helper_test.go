@@ -0,0 +1,17 @@
+package testdata
+
+import "testing"
+
+func TestHello_World(t *testing.T) {
+	check_hello(t)
+}
+
+func Example_hello() {
+}
+
+func check_hello(t *testing.T) {
+	if t == nil {
+		return
+	}
+	t.Log("hello")
+}
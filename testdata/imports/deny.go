@@ -0,0 +1,10 @@
+package imports
+
+import (
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	. "github.com/onsi/ginkgo"
+	. "strings"
+	"example.com/local/legacy/store"
+)
@@ -0,0 +1,27 @@
+package imports
+
+import (
+	"os"
+	"example.com/local/util"
+	"fmt"
+
+	"github.com/pkg/errors"
+	x "example.com/local/x"
+)
+
+import (
+	"fmt"
+
+	// errors with stack
+	"github.com/pkg/errors"
+	"example.com/local/util"
+)
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"example.com/local/util"
+)
@@ -0,0 +1,16 @@
+package testdata
+
+type HttpServer struct {
+	ServerUrl  string
+	apiKey     string
+	BucketKodo string
+}
+
+func (s *HttpServer) ServeHTTP() {
+}
+
+func parseUrl(rawUrl string) (userId int) {
+	jsonData, qpsLimit := rawUrl, 0
+	_, _ = jsonData, qpsLimit
+	return 0
+}
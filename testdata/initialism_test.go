@@ -0,0 +1,11 @@
+package testdata
+
+import "testing"
+
+func TestHttpServer(t *testing.T) {
+	startServer(t, "http://localhost")
+}
+
+func startServer(t *testing.T, serverUrl string) {
+	t.Log(serverUrl)
+}
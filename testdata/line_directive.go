@@ -0,0 +1,7 @@
+package testdata
+
+//line gen.y:10
+func Bad_Name() {}
+
+//checkstyle:ignore camel_name the name is from the grammar
+func Ignored_Name() {}
@@ -0,0 +1,18 @@
+package testdata
+
+//go:generate stringer -type=Color -output=color_string.go -linecomment -trimprefix=Color ./color.go
+
+import (
+	verylongname "github.com/qiniu/checkstyle/testdata/some/very/long/import/path/that/is/too/long"
+)
+
+// See https://github.com/qiniu/checkstyle/blob/master/README.md#config for more details.
+const message = "this is a very long message which could not be split into the lines easily"
+
+func lineLength(a, b, c int) int {
+	return verylongname.Add(a, b) + verylongname.Add(b, c) + verylongname.Add(a, c)
+	// a comment without url, but it is still too long for the limit of the lines here
+}
+
+//line gen.y:10:900
+const generated = "this is a very long message which is generated from the grammar file"
@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"fmt"
+)
+
+func hello(a int) {
+	if a > 0 {
+		for i := 0; i < a; i++ {
+			switch i {
+			case 1:
+				fmt.Println("one")
+			}
+		}
+	}
+}
+
+var world = func(a int) {
+	for i := 0; i < a; i++ {
+		if i > 0 {
+			func() {
+				fmt.Println("closure")
+			}()
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package testdata
+
+func short() (n int) {
+	return
+}
+
+func parse(s string) (n int, err error) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return
+		}
+		n = n*10 + int(c-'0')
+	}
+	f := func() (ok bool) {
+		return
+	}
+	_ = f
+	return
+}
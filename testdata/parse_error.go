@@ -0,0 +1,6 @@
+package testdata
+
+//line gen.y:10
+func hello() {
+	fmt.Println("hello"
+}
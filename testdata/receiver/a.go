@@ -0,0 +1,17 @@
+package receiver
+
+type counter struct {
+	n int
+}
+
+func (c *counter) Inc() {
+	c.n++
+}
+
+func (c *counter) Dec() {
+	c.n--
+}
+
+func (this *counter) Reset() {
+	this.n = 0
+}
@@ -0,0 +1,5 @@
+package receiver
+
+func (this *counter) set(n int) {
+	this.n = n
+}
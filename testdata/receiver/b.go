@@ -0,0 +1,15 @@
+package receiver
+
+func (cnt *counter) Value() int {
+	return cnt.n
+}
+
+func (_ counter) Name() string {
+	return "counter"
+}
+
+type buffer []byte
+
+func (buf buffer) Len() int {
+	return len(buf)
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"context"
+)
+
+type flag bool
+
+type error2 = error
+
+type Store interface {
+	Get(key string, ctx context.Context) (error, []byte)
+}
+
+func get(ctx context.Context, key string) ([]byte, error) {
+	return nil, nil
+}
+
+func open(name string, ctx context.Context, create, truncate, sync bool) (err error2, n int) {
+	return nil, 0
+}
+
+func options(verbose bool, debug, dryRun flag, ctx interface{}) {
+}
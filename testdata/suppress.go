@@ -0,0 +1,21 @@
+//checkstyle:file-ignore func_line generated by hand
+
+package testdata
+
+import (
+	"fmt"
+)
+
+const A_B = 0 //checkstyle:ignore camel_name compatible with the old api
+
+const B_C = 0
+
+//checkstyle:ignore camel_name,params_num
+func Hello_World(a, b, c, d int) {
+	var X_Y = 0
+	fmt.Println(X_Y)
+}
+
+func World(A_B int) {
+	fmt.Println("world")
+}
@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"errors"
+	"os"
+)
+
+type closer struct{}
+
+func (c closer) Close() error {
+	return errors.New("closed")
+}
+
+func open(name string) (*os.File, error) {
+	err := closer{}.Close()
+	return nil, err
+}
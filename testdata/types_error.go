@@ -0,0 +1,6 @@
+package testdata
+
+func broken() error {
+	err := undefined()
+	return err
+}
@@ -0,0 +1,255 @@
+package checkstyle
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// commandConfig is the config read by gocheckstyle and the extends, they
+// are validated along with the checker config.
+type commandConfig struct {
+	Ignore  []string    `json:"ignore"`
+	Root    bool        `json:"root"`
+	Extends extendsList `json:"extends"`
+}
+
+// extendsList is a name or a list of names.
+type extendsList []string
+
+func (l *extendsList) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*l = extendsList{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// ConfigErrors is the list of the invalid values in a config.
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// configFields returns the json keys of the global config and their types.
+func configFields() map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for _, t := range []reflect.Type{reflect.TypeOf(checker{}), reflect.TypeOf(commandConfig{})} {
+		for i := 0; i < t.NumField(); i++ {
+			if tag := t.Field(i).Tag.Get("json"); tag != "" {
+				fields[tag] = t.Field(i).Type
+			}
+		}
+	}
+	return fields
+}
+
+// RuleNames returns the names of the registered rules.
+func RuleNames() []string {
+	names := make([]string, len(registry))
+	for i, factory := range registry {
+		names[i] = factory().Name()
+	}
+	return names
+}
+
+// problemTypes returns the problem types could be put in fatal and severity.
+func problemTypes() []string {
+	types := []string{string(ParseError), string(Suppress)}
+	for _, factory := range registry {
+		types = append(types, string(factory().Type()))
+	}
+	return types
+}
+
+// ValidateConfig checks the config strictly, it reports the unknown keys,
+// the unknown rules in fatal and severity, and the invalid values of the
+// rules. The returned error is ConfigErrors if the config is invalid.
+func ValidateConfig(config []byte) error {
+	config, err := ParseConfig("", config)
+	if err != nil {
+		return err
+	}
+	var raws map[string]json.RawMessage
+	err = json.Unmarshal(config, &raws)
+	if err != nil {
+		return err
+	}
+	var errs ConfigErrors
+	fields := configFields()
+	rules := map[string]Rule{}
+	for _, factory := range registry {
+		rule := factory()
+		rules[rule.Name()] = rule
+	}
+
+	for _, key := range sortedKeys(raws) {
+		if strings.HasPrefix(key, "_") {
+			// the keys starting with _ are comments
+			continue
+		}
+		if t, ok := fields[key]; ok {
+			errs = append(errs, validateField(key, raws[key], t)...)
+		} else if rule, ok := rules[key]; ok {
+			if _, err := rule.Decode(raws[key]); err != nil {
+				errs = append(errs, newConfigError(key, err))
+			}
+		} else {
+			candidates := RuleNames()
+			for k := range fields {
+				candidates = append(candidates, k)
+			}
+			errs = append(errs, &ConfigError{Key: key, Err: errors.New("unknown key" + suggest(key, candidates))})
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validateField(key string, raw json.RawMessage, t reflect.Type) (errs ConfigErrors) {
+	if key == "severity" {
+		return validateSeverity(raw)
+	} else if key == "tests" {
+		return validateTests(raw)
+	}
+	value := reflect.New(t)
+	err := json.Unmarshal(raw, value.Interface())
+	if err != nil {
+		return ConfigErrors{newConfigError(key, err)}
+	}
+	if key == "fatal" {
+		for i, name := range value.Elem().Interface().([]string) {
+			errs = append(errs, validateType(key+"["+strconv.Itoa(i)+"]", name)...)
+		}
+	}
+	return errs
+}
+
+func validateSeverity(raw json.RawMessage) (errs ConfigErrors) {
+	var severities map[string]json.RawMessage
+	err := json.Unmarshal(raw, &severities)
+	if err != nil {
+		return ConfigErrors{newConfigError("severity", err)}
+	}
+	for _, name := range sortedKeys(severities) {
+		path := "severity." + name
+		errs = append(errs, validateType(path, name)...)
+		var severity Severity
+		if err := json.Unmarshal(severities[name], &severity); err != nil {
+			errs = append(errs, newConfigError(path, err))
+		}
+	}
+	return errs
+}
+
+// validateTests checks the tests block, which only contains rules.
+func validateTests(raw json.RawMessage) (errs ConfigErrors) {
+	var raws map[string]json.RawMessage
+	err := json.Unmarshal(raw, &raws)
+	if err != nil {
+		return ConfigErrors{newConfigError("tests", err)}
+	}
+	for _, name := range sortedKeys(raws) {
+		path := "tests." + name
+		rule := newRule(name)
+		if rule == nil {
+			err := errors.New("unknown rule" + suggest(name, RuleNames()))
+			errs = append(errs, &ConfigError{Key: path, Err: err})
+		} else if _, err := rule.Decode(raws[name]); err != nil {
+			errs = append(errs, newConfigError(path, err))
+		}
+	}
+	return errs
+}
+
+func newRule(name string) Rule {
+	for _, factory := range registry {
+		if rule := factory(); rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+func validateType(path, name string) ConfigErrors {
+	types := problemTypes()
+	for _, v := range types {
+		if v == name {
+			return nil
+		}
+	}
+	err := errors.New("unknown rule \"" + name + "\"" + suggest(name, types))
+	return ConfigErrors{{Key: path, Err: err}}
+}
+
+func newConfigError(key string, err error) *ConfigError {
+	if e, ok := err.(*json.UnmarshalTypeError); ok {
+		err = errors.New("expect " + e.Type.String() + " but got " + e.Value)
+	}
+	return &ConfigError{Key: key, Err: err}
+}
+
+// suggest returns the hint of the nearest candidate to name.
+func suggest(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/2+1
+	for _, v := range candidates {
+		if d := editDistance(name, v); d < bestDistance {
+			best, bestDistance = v, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return ", did you mean \"" + best + "\"?"
+}
+
+// editDistance is the Levenshtein distance of a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,52 @@
+package checkstyle
+
+import (
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	err := ValidateConfig([]byte(`{
+		"func_line": 50,
+		"_func_line_comment": "function line count limit",
+		"fatal": ["formated"],
+		"severity": {"camel_name": "info"},
+		"ignore": ["tmp/*"],
+		"tests": {"func_line": 100}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ValidateConfig([]byte(`{
+		"func_lines": 50,
+		"file_line": -1,
+		"params_num": "4",
+		"fatal": ["formated", "formatted"],
+		"severity": {"camel_nme": "info", "pkg_name": "fatal"},
+		"ignore": "tmp/*",
+		"tests": {"file_lin": 100, "func_line": true}
+	}`))
+	errs, ok := err.(ConfigErrors)
+	if !ok {
+		t.Fatal("expect config errors but ", err)
+	}
+	expect := []string{
+		"checkstyle: config fatal[1]: unknown rule \"formatted\", did you mean \"formated\"?",
+		"checkstyle: config file_line: limit -1 is negative",
+		"checkstyle: config func_lines: unknown key, did you mean \"func_line\"?",
+		"checkstyle: config ignore: expect []string but got string",
+		"checkstyle: config params_num: expect int but got string",
+		"checkstyle: config severity.camel_nme: unknown rule \"camel_nme\", did you mean \"camel_name\"?",
+		"checkstyle: config severity.pkg_name: unknown severity fatal",
+		"checkstyle: config tests.file_lin: unknown rule, did you mean \"file_line\"?",
+		"checkstyle: config tests.func_line: expect int but got bool",
+	}
+	if len(errs) != len(expect) {
+		t.Fatal("expect 9 errors but ", err)
+	}
+	for i, v := range errs {
+		if v.Error() != expect[i] {
+			t.Fatal("unexpected error ", v)
+		}
+	}
+}